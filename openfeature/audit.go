@@ -0,0 +1,91 @@
+package openfeature
+
+import "time"
+
+// AuditRecord records a flag evaluation decision, see WithAuditSink.
+// Its fields are unexported so that the record is immutable once emitted.
+type AuditRecord struct {
+	flagKey      string
+	flagType     Type
+	value        interface{}
+	variant      string
+	reason       Reason
+	errorCode    ErrorCode
+	err          error
+	targetingKey string
+	providerName string
+	domain       string
+	timestamp    time.Time
+}
+
+func newAuditRecord(domain string, hookCtx HookContext, evalDetails InterfaceEvaluationDetails, err error) AuditRecord {
+	return AuditRecord{
+		flagKey:      evalDetails.FlagKey,
+		flagType:     evalDetails.FlagType,
+		value:        evalDetails.Value,
+		variant:      evalDetails.Variant,
+		reason:       evalDetails.Reason,
+		errorCode:    evalDetails.ErrorCode,
+		err:          err,
+		targetingKey: hookCtx.evaluationContext.targetingKey,
+		providerName: hookCtx.providerMetadata.Name,
+		domain:       domain,
+		timestamp:    time.Now(),
+	}
+}
+
+// FlagKey returns the key of the evaluated flag
+func (r AuditRecord) FlagKey() string {
+	return r.flagKey
+}
+
+// FlagType returns the type of the evaluated flag
+func (r AuditRecord) FlagType() Type {
+	return r.flagType
+}
+
+// Value returns the value the evaluation resulted in, which is the default value if the evaluation failed
+func (r AuditRecord) Value() interface{} {
+	return r.value
+}
+
+// Variant returns the variant the evaluation resulted in, if any
+func (r AuditRecord) Variant() string {
+	return r.variant
+}
+
+// Reason returns the reason of the resolved value
+func (r AuditRecord) Reason() Reason {
+	return r.reason
+}
+
+// ErrorCode returns the error code of the resolution, if it failed
+func (r AuditRecord) ErrorCode() ErrorCode {
+	return r.errorCode
+}
+
+// Err returns the error of the evaluation, if it failed
+func (r AuditRecord) Err() error {
+	return r.err
+}
+
+// TargetingKey returns the targeting key of the evaluation context the flag was evaluated with
+func (r AuditRecord) TargetingKey() string {
+	return r.targetingKey
+}
+
+// ProviderName returns the name of the provider which evaluated the flag. It is empty if the evaluation failed
+// before reaching a provider.
+func (r AuditRecord) ProviderName() string {
+	return r.providerName
+}
+
+// Domain returns the domain of the client which evaluated the flag
+func (r AuditRecord) Domain() string {
+	return r.domain
+}
+
+// Timestamp returns the time the evaluation completed
+func (r AuditRecord) Timestamp() time.Time {
+	return r.timestamp
+}
@@ -0,0 +1,60 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	var records []AuditRecord
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor,
+		WithAuditSink(func(record AuditRecord) {
+			records = append(records, record)
+		}),
+	)
+
+	ctrl := gomock.NewController(t)
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().Return(Metadata{Name: "audited"}).AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+	if err := client.api.SetProviderAndWait(mockProvider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "found", gomock.Any(), gomock.Any()).
+		Return(BoolResolutionDetail{
+			Value:                    true,
+			ProviderResolutionDetail: ProviderResolutionDetail{Variant: "on", Reason: TargetingMatchReason},
+		})
+	mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "missing", gomock.Any(), gomock.Any()).
+		Return(BoolResolutionDetail{
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewFlagNotFoundResolutionError("not found"),
+				Reason:          ErrorReason,
+			},
+		})
+
+	evalCtx := NewEvaluationContext("user-1", nil)
+	_, _ = client.BooleanValue(context.Background(), "found", false, evalCtx)
+	_, _ = client.BooleanValue(context.Background(), "missing", false, evalCtx)
+
+	if len(records) != 2 {
+		t.Fatalf("expected a record per evaluation, got %d", len(records))
+	}
+
+	found := records[0]
+	if found.FlagKey() != "found" || found.Value() != true || found.Variant() != "on" ||
+		found.Reason() != TargetingMatchReason || found.TargetingKey() != "user-1" ||
+		found.ProviderName() != "audited" || found.Domain() != t.Name() || found.Err() != nil || found.Timestamp().IsZero() {
+		t.Errorf("unexpected record of a successful evaluation %+v", found)
+	}
+
+	missing := records[1]
+	if missing.FlagKey() != "missing" || missing.Value() != false || missing.ErrorCode() != FlagNotFoundCode ||
+		missing.Reason() != ErrorReason || missing.Err() == nil {
+		t.Errorf("unexpected record of a failed evaluation %+v", missing)
+	}
+}
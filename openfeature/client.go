@@ -2,9 +2,16 @@ package openfeature
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/go-logr/logr"
@@ -40,30 +47,219 @@ type Client struct {
 	clientEventing    clientEvent
 	metadata          ClientMetadata
 	hooks             []Hook
+	typedHooks        map[Type][]Hook
 	evaluationContext EvaluationContext
 	domain            string
 
+	trackPanicHandler         func(trackingEventName string, recovered interface{})
+	evaluationErrorCallback   func(flagKey string, err error, code ErrorCode)
+	defaultRegistry           map[string]interface{}
+	generateCorrelationIDs    bool
+	auditSink                 func(AuditRecord)
+	validateFlagSchema        bool
+	emptyTargetingKeyPolicy   EmptyTargetingKeyPolicy
+	maxContextAttributes      int
+	truncateContextAttributes bool
+	parseJSONObjects          bool
+	onTypeMismatch            func(flagKey string, flagType Type, err error)
+	resultTransformer         func(InterfaceEvaluationDetails) InterfaceEvaluationDetails
+	deprecationHandler        func(flagKey string, message string)
+	defaultTimeout            time.Duration
+	defaultSerializer         func(interface{}) (map[string]interface{}, error)
+
 	mx sync.RWMutex
 }
 
 // interface guard to ensure that Client implements IClient
 var _ IClient = (*Client)(nil)
 
+// ClientOption applies a change to a Client at construction
+type ClientOption func(*Client)
+
+// WithTrackPanicHandler sets the handler invoked when the provider's Tracker panics during Track.
+// Tracking is best-effort, so the panic is recovered and reported to the handler instead of propagating to the
+// caller. By default, the panic is logged.
+func WithTrackPanicHandler(handler func(trackingEventName string, recovered interface{})) ClientOption {
+	return func(c *Client) {
+		c.trackPanicHandler = handler
+	}
+}
+
+// WithEvaluationErrorCallback sets a callback invoked whenever a flag evaluation of the client results in an error,
+// with the key of the evaluated flag, the returned error and its error code. This allows monitoring evaluation error
+// rates centrally rather than at every call site. The callback is invoked before the evaluation returns; a panic it
+// raises is recovered and logged.
+func WithEvaluationErrorCallback(callback func(flagKey string, err error, code ErrorCode)) ClientOption {
+	return func(c *Client) {
+		c.evaluationErrorCallback = callback
+	}
+}
+
+// WithGeneratedCorrelationIDs makes the client generate a correlation id for each flag evaluation and tracking event
+// whose context.Context does not carry one already (see WithCorrelationID), so that providers and hooks can always
+// retrieve one with CorrelationID.
+func WithGeneratedCorrelationIDs() ClientOption {
+	return func(c *Client) {
+		c.generateCorrelationIDs = true
+	}
+}
+
+// WithAuditSink sets a sink receiving an AuditRecord of every flag evaluation of the client, successful or not, once
+// the evaluation completed. Unlike hooks, the sink is meant for compliance: it receives every decision, with no
+// deduplication. Records are delivered before the evaluation returns, so a slow sink delays evaluations.
+func WithAuditSink(sink func(AuditRecord)) ClientOption {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// WithFlagSchemaValidation makes the client check the type of each evaluation against the type declared by the
+// provider, if it implements FlagSchema. Evaluating a flag with another type than the declared one fails with a
+// TYPE_MISMATCH error, without calling the provider. Flags missing from the schema are not validated.
+func WithFlagSchemaValidation() ClientOption {
+	return func(c *Client) {
+		c.validateFlagSchema = true
+	}
+}
+
+// EmptyTargetingKeyPolicy controls how the client handles an empty targeting key, see WithEmptyTargetingKeyPolicy
+type EmptyTargetingKeyPolicy int
+
+const (
+	// IncludeEmptyTargetingKey passes an empty targeting key set as attribute through to the provider (default)
+	IncludeEmptyTargetingKey EmptyTargetingKeyPolicy = iota
+	// OmitEmptyTargetingKey removes an empty targeting key from the flattened context given to the provider
+	OmitEmptyTargetingKey
+	// ErrorOnEmptyTargetingKey fails evaluations whose context holds an empty targeting key with an INVALID_CONTEXT
+	// error, without calling the provider
+	ErrorOnEmptyTargetingKey
+)
+
+// WithEmptyTargetingKeyPolicy sets how the client handles an empty targeting key. An empty targeting key is
+// ambiguous: providers may treat it as a real key rather than as a missing one. The targeting key given to
+// NewEvaluationContext is only included when not empty, so the policy applies to an empty "targetingKey" attribute.
+func WithEmptyTargetingKeyPolicy(policy EmptyTargetingKeyPolicy) ClientOption {
+	return func(c *Client) {
+		c.emptyTargetingKeyPolicy = policy
+	}
+}
+
+// WithMaxContextAttributes sets the maximum number of attributes of the evaluation context given to the provider,
+// the targeting key aside, as a safety valve against runaway context growth (e.g. a misbehaving hook or enricher).
+// Evaluations whose merged context exceeds the maximum fail with an INVALID_CONTEXT error, without calling the
+// provider. See WithTruncatedContextAttributes to truncate oversized contexts instead.
+func WithMaxContextAttributes(n int) ClientOption {
+	return func(c *Client) {
+		c.maxContextAttributes = n
+		c.truncateContextAttributes = false
+	}
+}
+
+// WithTruncatedContextAttributes sets the maximum number of attributes of the evaluation context given to the
+// provider, the targeting key aside. Oversized contexts are logged and truncated to the attributes coming first in
+// key order, so that the evaluation still reaches the provider.
+func WithTruncatedContextAttributes(n int) ClientOption {
+	return func(c *Client) {
+		c.maxContextAttributes = n
+		c.truncateContextAttributes = true
+	}
+}
+
+// WithJSONObjectParsing makes the client parse object flags resolved to a string as JSON, to support providers
+// serving objects as JSON strings (e.g. environment variables or HTTP text endpoints). The JSON is decoded into a
+// value of the type of the default value, or into generic maps and slices if the default value is nil. Evaluations
+// resolving to invalid JSON return the default value with a PARSE_ERROR error.
+func WithJSONObjectParsing() ClientOption {
+	return func(c *Client) {
+		c.parseJSONObjects = true
+	}
+}
+
+// WithStrictTypes makes type mismatches loud, to catch flags evaluated with the wrong type during development and
+// testing. Evaluations still return the default value with a TYPE_MISMATCH error, as required by the specification,
+// and additionally call onMismatch with the flag key, the requested type and the error. If onMismatch is nil, the
+// client panics instead, failing the test at hand. Strict types are meant for non-production builds.
+func WithStrictTypes(onMismatch func(flagKey string, flagType Type, err error)) ClientOption {
+	return func(c *Client) {
+		if onMismatch == nil {
+			onMismatch = func(flagKey string, flagType Type, err error) {
+				panic(fmt.Sprintf("flag %s evaluated as %s: %v", flagKey, flagType, err))
+			}
+		}
+		c.onTypeMismatch = onMismatch
+	}
+}
+
+// WithResultTransformer sets a function transforming the details of every successful evaluation of the client before
+// they are returned, e.g. to clamp a numeric flag to a safe range in a single place rather than at every call site.
+//
+// Unlike after hooks, the transformer changes the value received by the caller: use it with care. It runs once the
+// evaluation completed, after the finally hooks and the audit sink, which both see the resolved value. A transformed
+// value of another type than the evaluated one is a TYPE_MISMATCH, and the default value is returned instead.
+func WithResultTransformer(transformer func(InterfaceEvaluationDetails) InterfaceEvaluationDetails) ClientOption {
+	return func(c *Client) {
+		c.resultTransformer = transformer
+	}
+}
+
+// WithDeprecationHandler sets a handler invoked on each evaluation of a flag the provider marks as deprecated, with
+// the DeprecatedFlagMetadataKey flag metadata, to find evaluations of flags due for removal. The message is the
+// deprecation message set by the provider, empty if the flag metadata is just true.
+func WithDeprecationHandler(handler func(flagKey string, message string)) ClientOption {
+	return func(c *Client) {
+		c.deprecationHandler = handler
+	}
+}
+
+// WithDefaultTimeout bounds every evaluation of the client by the given timeout, unless overridden with WithTimeout,
+// to enforce a latency budget in a single place rather than at every call site. The context given to hooks and to the
+// provider gets a deadline, unless it already has a sooner one. Providers must honor context cancellation for the
+// timeout to be effective, see NewTimeoutProvider otherwise. The budget is shared by hooks and provider as described
+// for WithTimeout.
+func WithDefaultTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = timeout
+	}
+}
+
 // NewClient returns a new Client. Name is a unique identifier for this client
 // This helper exists for historical reasons. It is recommended to interact with IEvaluation to derive IClient instances.
-func NewClient(domain string) *Client {
-	return newClient(domain, api, eventing)
+func NewClient(domain string, options ...ClientOption) *Client {
+	return newClient(domain, api, eventing, options...)
 }
 
-func newClient(domain string, apiRef evaluationImpl, eventRef clientEvent) *Client {
-	return &Client{
+func newClient(domain string, apiRef evaluationImpl, eventRef clientEvent, options ...ClientOption) *Client {
+	client := &Client{
 		domain:            domain,
 		api:               apiRef,
 		clientEventing:    eventRef,
 		metadata:          ClientMetadata{domain: domain},
 		hooks:             []Hook{},
 		evaluationContext: EvaluationContext{},
+		trackPanicHandler: logTrackPanic,
 	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// logTrackPanic is the default handler for panics recovered during Track
+func logTrackPanic(trackingEventName string, recovered interface{}) {
+	slog.Error("recovered from a panic in provider tracking", "event", trackingEventName, "panic", recovered)
+}
+
+// runEvaluationCallback runs a user callback observing the evaluation of the flag, e.g. the audit sink. A panic of the
+// callback is recovered and logged, so that it cannot fail the evaluation nor skip the other callbacks.
+func runEvaluationCallback(name string, flag string, callback func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("recovered from a panic in an evaluation callback", "callback", name, "flag", flag, "panic", r)
+		}
+	}()
+	callback()
 }
 
 // State returns the state of the associated provider
@@ -71,6 +267,51 @@ func (c *Client) State() State {
 	return c.clientEventing.State(c.domain)
 }
 
+// Clone returns a copy of the client, bound to the same domain and thus to the same provider, with copies of its hooks,
+// evaluation context and options. Hooks and evaluation context of the copy can be changed without affecting the
+// original client, e.g. to derive specialized clients from a base configuration. The evaluation context is immutable,
+// hence shared.
+// Event handlers are registered per domain, so handlers added to the copy also apply to the original client.
+func (c *Client) Clone() *Client {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	clone := &Client{
+		api:                       c.api,
+		clientEventing:            c.clientEventing,
+		metadata:                  c.metadata,
+		hooks:                     append([]Hook{}, c.hooks...),
+		evaluationContext:         c.evaluationContext,
+		domain:                    c.domain,
+		trackPanicHandler:         c.trackPanicHandler,
+		evaluationErrorCallback:   c.evaluationErrorCallback,
+		generateCorrelationIDs:    c.generateCorrelationIDs,
+		auditSink:                 c.auditSink,
+		validateFlagSchema:        c.validateFlagSchema,
+		emptyTargetingKeyPolicy:   c.emptyTargetingKeyPolicy,
+		maxContextAttributes:      c.maxContextAttributes,
+		truncateContextAttributes: c.truncateContextAttributes,
+		parseJSONObjects:          c.parseJSONObjects,
+		onTypeMismatch:            c.onTypeMismatch,
+		resultTransformer:         c.resultTransformer,
+		deprecationHandler:        c.deprecationHandler,
+		defaultTimeout:            c.defaultTimeout,
+		defaultSerializer:         c.defaultSerializer,
+	}
+
+	if c.typedHooks != nil {
+		clone.typedHooks = make(map[Type][]Hook, len(c.typedHooks))
+		for flagType, hooks := range c.typedHooks {
+			clone.typedHooks[flagType] = append([]Hook{}, hooks...)
+		}
+	}
+	if c.defaultRegistry != nil {
+		clone.SetDefaultRegistry(c.defaultRegistry)
+	}
+
+	return clone
+}
+
 // Deprecated
 // WithLogger sets the logger of the client
 func (c *Client) WithLogger(l logr.Logger) *Client {
@@ -93,6 +334,51 @@ func (c *Client) AddHooks(hooks ...Hook) {
 	c.hooks = append(c.hooks, hooks...)
 }
 
+// AddHooksForType appends to the client hooks that only run for evaluations of flags of the given type, e.g. a
+// validation hook only relevant to object flags. They run after the hooks added with AddHooks.
+func (c *Client) AddHooksForType(flagType Type, hooks ...Hook) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.typedHooks == nil {
+		c.typedHooks = map[Type][]Hook{}
+	}
+	c.typedHooks[flagType] = append(c.typedHooks[flagType], hooks...)
+}
+
+// EffectiveHooks lists the hooks registered for the evaluations of a client, per scope, see Client.EffectiveHooks.
+// Within each scope, hooks are listed in the order their before stage runs.
+type EffectiveHooks struct {
+	// API holds the hooks added to the API
+	API []Hook
+	// Client holds the hooks added to the client with AddHooks
+	Client []Hook
+	// ClientByType holds the hooks added to the client with AddHooksForType, by flag type
+	ClientByType map[Type][]Hook
+	// Provider holds the hooks of the provider used by the client
+	Provider []Hook
+}
+
+// EffectiveHooks returns copies of the hooks that run for the evaluations of the client, from each scope, to inspect
+// the hook chain e.g. when debugging a hook interfering with evaluations. Hooks run in the order API, client,
+// invocation (see WithHooks) and provider for the before stage, and in reverse order for the other stages.
+func (c *Client) EffectiveHooks() EffectiveHooks {
+	provider, apiHooks, _ := c.api.ForEvaluation(c.metadata.domain)
+
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	hooks := EffectiveHooks{
+		API:          append([]Hook{}, apiHooks...),
+		Client:       append([]Hook{}, c.hooks...),
+		ClientByType: make(map[Type][]Hook, len(c.typedHooks)),
+		Provider:     append([]Hook{}, provider.Hooks()...),
+	}
+	for flagType, typedHooks := range c.typedHooks {
+		hooks.ClientByType[flagType] = append([]Hook{}, typedHooks...)
+	}
+	return hooks
+}
+
 // AddHandler allows to add Client level event handler
 func (c *Client) AddHandler(eventType EventType, callback EventCallback) {
 	c.clientEventing.AddClientHandler(c.metadata.Domain(), eventType, callback)
@@ -146,6 +432,12 @@ type EvaluationDetails struct {
 	ResolutionDetail
 }
 
+// IsDisabled returns whether the flag is disabled in the provider, with the DISABLED reason. Disabled flags resolve to
+// the default value, without error: being disabled is a normal state of a flag, unlike not being found.
+func (e EvaluationDetails) IsDisabled() bool {
+	return e.Reason == DisabledReason
+}
+
 type BooleanEvaluationDetails struct {
 	Value bool
 	EvaluationDetails
@@ -171,11 +463,20 @@ type InterfaceEvaluationDetails struct {
 	EvaluationDetails
 }
 
+// DurationEvaluationDetails holds the result of a duration evaluation, see Client.DurationValueDetails.
+type DurationEvaluationDetails struct {
+	Value time.Duration
+	EvaluationDetails
+}
+
 type ResolutionDetail struct {
 	Variant      string
 	Reason       Reason
 	ErrorCode    ErrorCode
 	ErrorMessage string
+	// ErrorCause is the underlying error reported by the provider along with the error code, if any.
+	// See ResolutionError.WithCause.
+	ErrorCause   error
 	FlagMetadata FlagMetadata
 }
 
@@ -184,6 +485,46 @@ type ResolutionDetail struct {
 // Author (via the Evaluation API) or an Application Integrator (via hooks).
 type FlagMetadata map[string]interface{}
 
+// DeprecatedFlagMetadataKey is the flag metadata key marking a flag as deprecated, with either true or a deprecation
+// message, see WithDeprecationHandler
+const DeprecatedFlagMetadataKey = "deprecated"
+
+// deprecation returns the deprecation message of the flag, and whether the flag is deprecated
+func (f FlagMetadata) deprecation() (string, bool) {
+	switch deprecated := f[DeprecatedFlagMetadataKey].(type) {
+	case bool:
+		return "", deprecated
+	case string:
+		return deprecated, deprecated != ""
+	default:
+		return "", false
+	}
+}
+
+// ValueAgeMsFlagMetadataKey is the flag metadata key holding the age, in milliseconds, of the data a flag was resolved
+// from, for providers implementing FreshnessReporter
+const ValueAgeMsFlagMetadataKey = "valueAgeMs"
+
+// withValueAge returns a copy of the flag metadata holding the age of the data served by the provider, if it is a
+// FreshnessReporter knowing when its data was last updated. Metadata set by the provider takes precedence.
+func (f FlagMetadata) withValueAge(provider FeatureProvider) FlagMetadata {
+	reporter, ok := provider.(FreshnessReporter)
+	if !ok {
+		return f
+	}
+	lastUpdated := reporter.LastUpdated()
+	if lastUpdated.IsZero() {
+		return f
+	}
+
+	metadata := make(FlagMetadata, len(f)+1)
+	metadata[ValueAgeMsFlagMetadataKey] = time.Since(lastUpdated).Milliseconds()
+	for key, value := range f {
+		metadata[key] = value
+	}
+	return metadata
+}
+
 // GetString fetch string value from FlagMetadata.
 // Returns an error if the key does not exist, or, the value is of the wrong type
 func (f FlagMetadata) GetString(key string) (string, error) {
@@ -215,7 +556,7 @@ func (f FlagMetadata) GetBool(key string) (bool, error) {
 }
 
 // GetInt fetch int64 value from FlagMetadata.
-// Returns an error if the key does not exist, or, the value is of the wrong type
+// Returns an error if the key does not exist, the value is of the wrong type, or, an unsigned value overflows int64
 func (f FlagMetadata) GetInt(key string) (int64, error) {
 	v, ok := f[key]
 	if !ok {
@@ -232,11 +573,56 @@ func (f FlagMetadata) GetInt(key string) (int64, error) {
 		return int64(v.(int32)), nil
 	case int64:
 		return v.(int64), nil
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := toUint64(t)
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d for key %s overflows int64", u, key)
+		}
+		return int64(u), nil
 	default:
 		return 0, fmt.Errorf("wrong type for key %s, expected integer, got %T", key, t)
 	}
 }
 
+// GetUint fetch uint64 value from FlagMetadata.
+// Returns an error if the key does not exist, the value is of the wrong type, or, a signed value is negative
+func (f FlagMetadata) GetUint(key string) (uint64, error) {
+	v, ok := f[key]
+	if !ok {
+		return 0, fmt.Errorf("key %s does not exist in FlagMetadata", key)
+	}
+	if u, ok := toUint64(v); ok {
+		return u, nil
+	}
+
+	i, err := f.GetInt(key)
+	if err != nil {
+		return 0, fmt.Errorf("wrong type for key %s, expected integer, got %T", key, v)
+	}
+	if i < 0 {
+		return 0, fmt.Errorf("value %d for key %s is negative", i, key)
+	}
+	return uint64(i), nil
+}
+
+// toUint64 converts unsigned integer values to uint64
+func toUint64(v interface{}) (uint64, bool) {
+	switch t := v.(type) {
+	case uint:
+		return uint64(t), true
+	case uint8:
+		return uint64(t), true
+	case uint16:
+		return uint64(t), true
+	case uint32:
+		return uint64(t), true
+	case uint64:
+		return t, true
+	default:
+		return 0, false
+	}
+}
+
 // GetFloat fetch float64 value from FlagMetadata.
 // Returns an error if the key does not exist, or, the value is of the wrong type
 func (f FlagMetadata) GetFloat(key string) (float64, error) {
@@ -259,8 +645,36 @@ type Option func(*EvaluationOptions)
 
 // EvaluationOptions should contain a list of hooks to be executed for a flag evaluation
 type EvaluationOptions struct {
-	hooks     []Hook
-	hookHints HookHints
+	hooks            []Hook
+	hookHints        HookHints
+	exclusiveContext bool
+	hookTracer       func(event HookTraceEvent)
+	providerOptions  map[string]interface{}
+	timeout          time.Duration
+	requireProvider  bool
+	// applied holds the options the EvaluationOptions were built from, see NewEvaluationOptions
+	applied []Option
+}
+
+// NewEvaluationOptions builds EvaluationOptions from the given options, e.g. to compute a set of options once and
+// apply it to several evaluations with WithOptions
+func NewEvaluationOptions(options ...Option) EvaluationOptions {
+	var evalOptions EvaluationOptions
+	for _, option := range options {
+		option(&evalOptions)
+	}
+	evalOptions.applied = append([]Option{}, options...)
+	return evalOptions
+}
+
+// WithOptions applies EvaluationOptions built with NewEvaluationOptions, as if their options were given in its place.
+// Options given before or after it apply as usual, e.g. later hooks replace the hooks of the EvaluationOptions.
+func WithOptions(evalOptions EvaluationOptions) Option {
+	return func(options *EvaluationOptions) {
+		for _, option := range evalOptions.applied {
+			option(options)
+		}
+	}
 }
 
 // HookHints returns evaluation options' hook hints
@@ -287,6 +701,68 @@ func WithHookHints(hookHints HookHints) Option {
 	}
 }
 
+// WithExclusiveContext makes the invocation evaluation context the sole context of the evaluation.
+// The API (global), transaction and client contexts are not merged in. This deviates from the specification's merge
+// hierarchy and is intended as a debugging and testing aid for reproducing how a flag resolves for one precise context.
+func WithExclusiveContext() Option {
+	return func(options *EvaluationOptions) {
+		options.exclusiveContext = true
+	}
+}
+
+// WithTimeout bounds the evaluation by the given timeout, overriding the default timeout of the client (see
+// WithDefaultTimeout). The context given to hooks and to the provider gets a deadline, unless it already has a sooner
+// one. Providers must honor context cancellation for the timeout to be effective, see NewTimeoutProvider otherwise.
+//
+// The deadline is shared by all the stages of the evaluation, so that each hook only gets the budget left by the
+// previous ones. If the deadline is exceeded once the before hooks ran, the provider is not called and the evaluation
+// fails with the GENERAL error code, wrapping context.DeadlineExceeded. A context canceled otherwise does not skip
+// the provider.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *EvaluationOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithRequireProvider makes the evaluation fail closed when the provider is not READY: rather than resolving the flag
+// or returning the default value, the evaluation returns the zero value of the flag type along with an error wrapping
+// ErrProviderRequired, e.g. for critical flags for which serving the code default during a provider outage is not
+// acceptable. The error code is PROVIDER_FATAL for a FATAL provider, and PROVIDER_NOT_READY otherwise, including for
+// STALE and ERROR providers, and when no provider is set, i.e. the evaluation would use the NoopProvider.
+//
+// This deliberately departs from the default behavior of returning the default value on abnormal execution, for this
+// evaluation only. Callers must check the returned error, as methods without an error (e.g. Client.Boolean) return
+// the zero value.
+func WithRequireProvider() Option {
+	return func(options *EvaluationOptions) {
+		options.requireProvider = true
+	}
+}
+
+// WithHookTracing registers a callback receiving a HookTraceEvent for every hook invocation of the evaluation, in
+// execution order. This gives a precise timeline of the hook chain, which helps debugging hook interactions.
+func WithHookTracing(tracer func(event HookTraceEvent)) Option {
+	return func(options *EvaluationOptions) {
+		options.hookTracer = tracer
+	}
+}
+
+// traceHook reports the invocation of a hook to the hook tracer, if any
+func (e EvaluationOptions) traceHook(hookCtx HookContext, stage HookStage, hook scopedHook, start time.Time, err error) {
+	if e.hookTracer == nil {
+		return
+	}
+
+	e.hookTracer(HookTraceEvent{
+		FlagKey:  hookCtx.flagKey,
+		Stage:    stage,
+		Scope:    hook.scope,
+		HookType: fmt.Sprintf("%T", hook.Hook),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
 // BooleanValue performs a flag evaluation that returns a boolean.
 //
 // Parameters:
@@ -298,7 +774,7 @@ func WithHookHints(hookHints HookHints) Option {
 func (c *Client) BooleanValue(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) (bool, error) {
 	details, err := c.BooleanValueDetails(ctx, flag, defaultValue, evalCtx, options...)
 	if err != nil {
-		return defaultValue, err
+		return details.Value, err
 	}
 
 	return details.Value, nil
@@ -315,7 +791,7 @@ func (c *Client) BooleanValue(ctx context.Context, flag string, defaultValue boo
 func (c *Client) StringValue(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) (string, error) {
 	details, err := c.StringValueDetails(ctx, flag, defaultValue, evalCtx, options...)
 	if err != nil {
-		return defaultValue, err
+		return details.Value, err
 	}
 
 	return details.Value, nil
@@ -332,7 +808,7 @@ func (c *Client) StringValue(ctx context.Context, flag string, defaultValue stri
 func (c *Client) FloatValue(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (float64, error) {
 	details, err := c.FloatValueDetails(ctx, flag, defaultValue, evalCtx, options...)
 	if err != nil {
-		return defaultValue, err
+		return details.Value, err
 	}
 
 	return details.Value, nil
@@ -349,7 +825,7 @@ func (c *Client) FloatValue(ctx context.Context, flag string, defaultValue float
 func (c *Client) IntValue(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (int64, error) {
 	details, err := c.IntValueDetails(ctx, flag, defaultValue, evalCtx, options...)
 	if err != nil {
-		return defaultValue, err
+		return details.Value, err
 	}
 
 	return details.Value, nil
@@ -366,7 +842,7 @@ func (c *Client) IntValue(ctx context.Context, flag string, defaultValue int64,
 func (c *Client) ObjectValue(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
 	details, err := c.ObjectValueDetails(ctx, flag, defaultValue, evalCtx, options...)
 	if err != nil {
-		return defaultValue, err
+		return details.Value, err
 	}
 
 	return details.Value, nil
@@ -384,6 +860,8 @@ func (c *Client) BooleanValueDetails(ctx context.Context, flag string, defaultVa
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	defaultValue = providerDefault(c, flag, Boolean, defaultValue)
+
 	evalOptions := &EvaluationOptions{}
 	for _, option := range options {
 		option(evalOptions)
@@ -392,7 +870,7 @@ func (c *Client) BooleanValueDetails(ctx context.Context, flag string, defaultVa
 	evalDetails, err := c.evaluate(ctx, flag, Boolean, defaultValue, evalCtx, *evalOptions)
 	if err != nil {
 		return BooleanEvaluationDetails{
-			Value:             defaultValue,
+			Value:             failedValue(err, defaultValue),
 			EvaluationDetails: evalDetails.EvaluationDetails,
 		}, err
 	}
@@ -400,6 +878,7 @@ func (c *Client) BooleanValueDetails(ctx context.Context, flag string, defaultVa
 	value, ok := evalDetails.Value.(bool)
 	if !ok {
 		err := errors.New("evaluated value is not a boolean")
+		c.reportTypeMismatch(flag, Boolean, err)
 		boolEvalDetails := BooleanEvaluationDetails{
 			Value:             defaultValue,
 			EvaluationDetails: evalDetails.EvaluationDetails,
@@ -428,6 +907,8 @@ func (c *Client) StringValueDetails(ctx context.Context, flag string, defaultVal
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	defaultValue = providerDefault(c, flag, String, defaultValue)
+
 	evalOptions := &EvaluationOptions{}
 	for _, option := range options {
 		option(evalOptions)
@@ -436,7 +917,7 @@ func (c *Client) StringValueDetails(ctx context.Context, flag string, defaultVal
 	evalDetails, err := c.evaluate(ctx, flag, String, defaultValue, evalCtx, *evalOptions)
 	if err != nil {
 		return StringEvaluationDetails{
-			Value:             defaultValue,
+			Value:             failedValue(err, defaultValue),
 			EvaluationDetails: evalDetails.EvaluationDetails,
 		}, err
 	}
@@ -444,6 +925,7 @@ func (c *Client) StringValueDetails(ctx context.Context, flag string, defaultVal
 	value, ok := evalDetails.Value.(string)
 	if !ok {
 		err := errors.New("evaluated value is not a string")
+		c.reportTypeMismatch(flag, String, err)
 		strEvalDetails := StringEvaluationDetails{
 			Value:             defaultValue,
 			EvaluationDetails: evalDetails.EvaluationDetails,
@@ -472,6 +954,8 @@ func (c *Client) FloatValueDetails(ctx context.Context, flag string, defaultValu
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	defaultValue = providerDefault(c, flag, Float, defaultValue)
+
 	evalOptions := &EvaluationOptions{}
 	for _, option := range options {
 		option(evalOptions)
@@ -480,7 +964,7 @@ func (c *Client) FloatValueDetails(ctx context.Context, flag string, defaultValu
 	evalDetails, err := c.evaluate(ctx, flag, Float, defaultValue, evalCtx, *evalOptions)
 	if err != nil {
 		return FloatEvaluationDetails{
-			Value:             defaultValue,
+			Value:             failedValue(err, defaultValue),
 			EvaluationDetails: evalDetails.EvaluationDetails,
 		}, err
 	}
@@ -488,6 +972,7 @@ func (c *Client) FloatValueDetails(ctx context.Context, flag string, defaultValu
 	value, ok := evalDetails.Value.(float64)
 	if !ok {
 		err := errors.New("evaluated value is not a float64")
+		c.reportTypeMismatch(flag, Float, err)
 		floatEvalDetails := FloatEvaluationDetails{
 			Value:             defaultValue,
 			EvaluationDetails: evalDetails.EvaluationDetails,
@@ -516,6 +1001,8 @@ func (c *Client) IntValueDetails(ctx context.Context, flag string, defaultValue
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	defaultValue = providerDefault(c, flag, Int, defaultValue)
+
 	evalOptions := &EvaluationOptions{}
 	for _, option := range options {
 		option(evalOptions)
@@ -524,7 +1011,7 @@ func (c *Client) IntValueDetails(ctx context.Context, flag string, defaultValue
 	evalDetails, err := c.evaluate(ctx, flag, Int, defaultValue, evalCtx, *evalOptions)
 	if err != nil {
 		return IntEvaluationDetails{
-			Value:             defaultValue,
+			Value:             failedValue(err, defaultValue),
 			EvaluationDetails: evalDetails.EvaluationDetails,
 		}, err
 	}
@@ -532,6 +1019,7 @@ func (c *Client) IntValueDetails(ctx context.Context, flag string, defaultValue
 	value, ok := evalDetails.Value.(int64)
 	if !ok {
 		err := errors.New("evaluated value is not an int64")
+		c.reportTypeMismatch(flag, Int, err)
 		intEvalDetails := IntEvaluationDetails{
 			Value:             defaultValue,
 			EvaluationDetails: evalDetails.EvaluationDetails,
@@ -560,6 +1048,8 @@ func (c *Client) ObjectValueDetails(ctx context.Context, flag string, defaultVal
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
+	defaultValue = providerDefault(c, flag, Object, defaultValue)
+
 	evalOptions := &EvaluationOptions{}
 	for _, option := range options {
 		option(evalOptions)
@@ -648,6 +1138,279 @@ func (c *Client) Object(ctx context.Context, flag string, defaultValue interface
 	return value
 }
 
+// BooleanValueOrNotFound performs a flag evaluation that returns a boolean, along with whether the flag exists.
+// If the provider does not find the flag, the default value is returned with found set to false and no error, so
+// that optional flags can be handled without inspecting error codes. Other errors are returned as with
+// [BooleanValue], with found set to true.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) BooleanValueOrNotFound(ctx context.Context, flag string, defaultValue bool, evalCtx EvaluationContext, options ...Option) (value bool, found bool, err error) {
+	details, err := c.BooleanValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if details.ErrorCode == FlagNotFoundCode {
+		return details.Value, false, nil
+	}
+
+	return details.Value, true, err
+}
+
+// StringValueOrNotFound performs a flag evaluation that returns a string, along with whether the flag exists.
+// If the provider does not find the flag, the default value is returned with found set to false and no error, so
+// that optional flags can be handled without inspecting error codes. Other errors are returned as with
+// [StringValue], with found set to true.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) StringValueOrNotFound(ctx context.Context, flag string, defaultValue string, evalCtx EvaluationContext, options ...Option) (value string, found bool, err error) {
+	details, err := c.StringValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if details.ErrorCode == FlagNotFoundCode {
+		return details.Value, false, nil
+	}
+
+	return details.Value, true, err
+}
+
+// FloatValueOrNotFound performs a flag evaluation that returns a float64, along with whether the flag exists.
+// If the provider does not find the flag, the default value is returned with found set to false and no error, so
+// that optional flags can be handled without inspecting error codes. Other errors are returned as with
+// [FloatValue], with found set to true.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) FloatValueOrNotFound(ctx context.Context, flag string, defaultValue float64, evalCtx EvaluationContext, options ...Option) (value float64, found bool, err error) {
+	details, err := c.FloatValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if details.ErrorCode == FlagNotFoundCode {
+		return details.Value, false, nil
+	}
+
+	return details.Value, true, err
+}
+
+// IntValueOrNotFound performs a flag evaluation that returns an int64, along with whether the flag exists.
+// If the provider does not find the flag, the default value is returned with found set to false and no error, so
+// that optional flags can be handled without inspecting error codes. Other errors are returned as with
+// [IntValue], with found set to true.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) IntValueOrNotFound(ctx context.Context, flag string, defaultValue int64, evalCtx EvaluationContext, options ...Option) (value int64, found bool, err error) {
+	details, err := c.IntValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if details.ErrorCode == FlagNotFoundCode {
+		return details.Value, false, nil
+	}
+
+	return details.Value, true, err
+}
+
+// ObjectValueOrNotFound performs a flag evaluation that returns an object, along with whether the flag exists.
+// If the provider does not find the flag, the default value is returned with found set to false and no error, so
+// that optional flags can be handled without inspecting error codes. Other errors are returned as with
+// [ObjectValue], with found set to true.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) ObjectValueOrNotFound(ctx context.Context, flag string, defaultValue interface{}, evalCtx EvaluationContext, options ...Option) (value interface{}, found bool, err error) {
+	details, err := c.ObjectValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	if details.ErrorCode == FlagNotFoundCode {
+		return details.Value, false, nil
+	}
+
+	return details.Value, true, err
+}
+
+// Variant performs a flag evaluation that returns only the variant selected by the provider, e.g. to display it or
+// to route on its name. The flag is evaluated as an object flag, so that flags of any type can be evaluated. An error
+// is returned if the evaluation fails or if the provider did not report a variant.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) Variant(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	details, err := c.ObjectValueDetails(ctx, flag, nil, evalCtx, options...)
+	if err != nil {
+		return "", err
+	}
+	if details.Variant == "" {
+		return "", fmt.Errorf("flag %s resolved without a variant", flag)
+	}
+
+	return details.Variant, nil
+}
+
+// DurationValue performs a flag evaluation that returns a time.Duration. The flag is evaluated as a string flag and
+// its value is parsed with time.ParseDuration, e.g. "1.5s" or "250ms". If the evaluation or the parsing fails, the
+// default value is returned along with the error.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) DurationValue(ctx context.Context, flag string, defaultValue time.Duration, evalCtx EvaluationContext, options ...Option) (time.Duration, error) {
+	details, err := c.DurationValueDetails(ctx, flag, defaultValue, evalCtx, options...)
+	return details.Value, err
+}
+
+// DurationValueDetails performs a flag evaluation that returns an evaluation details struct holding a time.Duration.
+// The flag is evaluated as a string flag and its value is parsed with time.ParseDuration. If the value cannot be
+// parsed, the default value is returned with the PARSE_ERROR error code and the ERROR reason. The parsing happens once
+// the string evaluation completed, so a parse error is not seen by hooks, evaluation subscribers, provider stats, the
+// audit sink nor the evaluation error callback, which all observe a successful string evaluation.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - flag is the key that uniquely identifies a particular flag
+// - defaultValue is returned if an error occurs
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) DurationValueDetails(ctx context.Context, flag string, defaultValue time.Duration, evalCtx EvaluationContext, options ...Option) (DurationEvaluationDetails, error) {
+	strEvalDetails, err := c.StringValueDetails(ctx, flag, defaultValue.String(), evalCtx, options...)
+	if err != nil {
+		return DurationEvaluationDetails{
+			Value:             failedValue(err, defaultValue),
+			EvaluationDetails: strEvalDetails.EvaluationDetails,
+		}, err
+	}
+
+	value, err := time.ParseDuration(strEvalDetails.Value)
+	if err != nil {
+		err = fmt.Errorf("evaluated value is not a duration: %w", err)
+		durationEvalDetails := DurationEvaluationDetails{
+			Value:             defaultValue,
+			EvaluationDetails: strEvalDetails.EvaluationDetails,
+		}
+		durationEvalDetails.EvaluationDetails.ErrorCode = ParseErrorCode
+		durationEvalDetails.EvaluationDetails.ErrorMessage = err.Error()
+		durationEvalDetails.EvaluationDetails.Reason = ErrorReason
+
+		return durationEvalDetails, err
+	}
+
+	return DurationEvaluationDetails{
+		Value:             value,
+		EvaluationDetails: strEvalDetails.EvaluationDetails,
+	}, nil
+}
+
+// EvaluateByPrefix evaluates every flag whose key starts with the given prefix, e.g. "checkout." to load the
+// configuration of a whole feature area at once. The flags are enumerated from the provider, which must implement
+// FlagSchema, and each flag is evaluated with its declared type and the zero value of that type as default. If the
+// provider does not enumerate its flags, an error wrapping ErrOperationNotSupported is returned.
+//
+// The details of every flag are returned keyed by flag key, including those of failed evaluations. The errors of
+// failed evaluations are joined in the returned error.
+//
+// Parameters:
+// - ctx is the standard go context struct used to manage requests (e.g. timeouts)
+// - prefix is the prefix of the keys of the flags to evaluate
+// - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
+// - options are optional additional evaluation options e.g. WithHooks & WithHookHints
+func (c *Client) EvaluateByPrefix(ctx context.Context, prefix string, evalCtx EvaluationContext, options ...Option) (map[string]InterfaceEvaluationDetails, error) {
+	provider, _, _ := c.api.ForEvaluation(c.metadata.domain)
+	schema, ok := provider.(FlagSchema)
+	if !ok || schema.FlagSchema() == nil {
+		return nil, NewUnsupportedOperationError("EvaluateByPrefix")
+	}
+
+	flags := map[string]Type{}
+	for flag, flagType := range schema.FlagSchema() {
+		if strings.HasPrefix(flag, prefix) {
+			flags[flag] = flagType
+		}
+	}
+	keys := make([]string, 0, len(flags))
+	for flag := range flags {
+		keys = append(keys, flag)
+	}
+	sort.Strings(keys)
+
+	results := make(map[string]InterfaceEvaluationDetails, len(keys))
+	var errs []error
+	for _, flag := range keys {
+		details, err := c.interfaceValueDetails(ctx, flag, flags[flag], evalCtx, options...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("flag %s: %w", flag, err))
+		}
+		results[flag] = details
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// interfaceValueDetails evaluates a flag with the given type and its zero value as default
+func (c *Client) interfaceValueDetails(ctx context.Context, flag string, flagType Type, evalCtx EvaluationContext, options ...Option) (InterfaceEvaluationDetails, error) {
+	switch flagType {
+	case Boolean:
+		details, err := c.BooleanValueDetails(ctx, flag, false, evalCtx, options...)
+		return InterfaceEvaluationDetails{Value: details.Value, EvaluationDetails: details.EvaluationDetails}, err
+	case String:
+		details, err := c.StringValueDetails(ctx, flag, "", evalCtx, options...)
+		return InterfaceEvaluationDetails{Value: details.Value, EvaluationDetails: details.EvaluationDetails}, err
+	case Float:
+		details, err := c.FloatValueDetails(ctx, flag, 0, evalCtx, options...)
+		return InterfaceEvaluationDetails{Value: details.Value, EvaluationDetails: details.EvaluationDetails}, err
+	case Int:
+		details, err := c.IntValueDetails(ctx, flag, 0, evalCtx, options...)
+		return InterfaceEvaluationDetails{Value: details.Value, EvaluationDetails: details.EvaluationDetails}, err
+	default:
+		return c.ObjectValueDetails(ctx, flag, nil, evalCtx, options...)
+	}
+}
+
+// snapshotKey is the context key of a provider snapshot pinned by Client.Snapshot for a domain
+type snapshotKey struct {
+	domain string
+}
+
+// Snapshot pins the flag configuration of the client's provider, if the provider implements Snapshotter.
+// Evaluations of this client, or of any client of the same domain, using the returned context (or a context derived
+// from it) resolve flags from the pinned snapshot, so that all decisions taken within a single request are
+// consistent even if the provider configuration changes meanwhile. The snapshot stays in use for the returned
+// context even if the provider is replaced.
+//
+// If the provider does not implement Snapshotter, or cannot pin its configuration, the given context is returned and
+// evaluations behave normally.
+func (c *Client) Snapshot(ctx context.Context) (context.Context, error) {
+	provider, _, _ := c.api.ForEvaluation(c.metadata.domain)
+	snapshotter, ok := provider.(Snapshotter)
+	if !ok {
+		return ctx, nil
+	}
+
+	snapshot, err := snapshotter.Snapshot(ctx)
+	if err != nil {
+		return ctx, fmt.Errorf("snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return ctx, nil
+	}
+
+	return context.WithValue(ctx, snapshotKey{domain: c.metadata.domain}, snapshot), nil
+}
+
 // Track performs an action for tracking for occurrence  of a particular action or application state.
 //
 // Parameters:
@@ -655,8 +1418,21 @@ func (c *Client) Object(ctx context.Context, flag string, defaultValue interface
 // - trackingEventName is the event name to track
 // - evalCtx is the evaluation context used in a flag evaluation (not to be confused with ctx)
 // - trackingEventDetails defines optional data pertinent to a particular
+//
+// Tracking is best-effort: a panic raised by the provider's Tracker is recovered and reported to the handler set
+// with WithTrackPanicHandler.
 func (c *Client) Track(ctx context.Context, trackingEventName string, evalCtx EvaluationContext, details TrackingEventDetails) {
+	if c.generateCorrelationIDs {
+		ctx = ensureCorrelationID(ctx)
+	}
 	provider, evalCtx := c.forTracking(ctx, evalCtx)
+
+	defer func() {
+		if r := recover(); r != nil && c.trackPanicHandler != nil {
+			c.trackPanicHandler(trackingEventName, r)
+		}
+	}()
+
 	provider.Track(ctx, trackingEventName, evalCtx, details)
 }
 
@@ -667,9 +1443,11 @@ func (c *Client) Track(ctx context.Context, trackingEventName string, evalCtx Ev
 // - transaction
 // - client
 // - invocation (highest precedence)
+//
+// unless the merge precedence was changed with SetContextMergePrecedence.
 func (c *Client) forTracking(ctx context.Context, evalCtx EvaluationContext) (Tracker, EvaluationContext) {
 	provider, _, apiCtx := c.api.ForEvaluation(c.metadata.domain)
-	evalCtx = mergeContexts(evalCtx, c.evaluationContext, TransactionContext(ctx), apiCtx)
+	evalCtx = c.mergeContextLevels(ctx, evalCtx, apiCtx)
 	trackingProvider, ok := provider.(Tracker)
 	if !ok {
 		trackingProvider = NoopProvider{}
@@ -677,10 +1455,78 @@ func (c *Client) forTracking(ctx context.Context, evalCtx EvaluationContext) (Tr
 	return trackingProvider, evalCtx
 }
 
+// ResolveContext returns the effective evaluation context a flag evaluation with the given context and invocation
+// context resolves with, flattened as seen by the provider, without evaluating any flag. The API, transaction, client
+// and invocation contexts are merged, and enriched by the provider if it is a ContextEnricher. Contexts returned by
+// before hooks are not included, as hooks depend on the evaluated flag.
+//
+// This is meant for debugging and tooling, e.g. to display the effective targeting context of a user.
+func (c *Client) ResolveContext(ctx context.Context, invocationCtx EvaluationContext) FlattenedContext {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	provider, _, apiCtx := c.api.ForEvaluation(c.metadata.domain)
+	evalCtx := c.mergeContextLevels(ctx, invocationCtx, apiCtx)
+	evalCtx = withDefaultContext(provider, evalCtx)
+	if enricher, ok := provider.(ContextEnricher); ok {
+		evalCtx = enrichContext(ctx, enricher, evalCtx)
+	}
+	return flattenContext(evalCtx)
+}
+
+// mergeContextLevels merges the API, transaction, client and invocation contexts according to the API's merge
+// precedence
+func (c *Client) mergeContextLevels(ctx context.Context, invocationCtx EvaluationContext, apiCtx EvaluationContext) EvaluationContext {
+	return mergeContextLevels(c.api.ContextMergePrecedence(), map[ContextLevel]EvaluationContext{
+		APIContextLevel:         apiCtx,
+		TransactionContextLevel: TransactionContext(ctx),
+		ClientContextLevel:      c.evaluationContext,
+		InvocationContextLevel:  invocationCtx,
+	})
+}
+
 func (c *Client) evaluate(
 	ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx EvaluationContext, options EvaluationOptions,
 ) (InterfaceEvaluationDetails, error) {
-	evalDetails := InterfaceEvaluationDetails{
+	if c.generateCorrelationIDs {
+		ctx = ensureCorrelationID(ctx)
+	}
+	timeout := c.defaultTimeout
+	if options.timeout > 0 {
+		timeout = options.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	evalDetails, err := c.evaluateFlag(ctx, flag, flagType, defaultValue, evalCtx, options)
+	if err == nil && c.resultTransformer != nil {
+		evalDetails = c.resultTransformer(evalDetails)
+	}
+	c.api.recordEvaluation(c.metadata.domain, evalDetails, err)
+	if c.deprecationHandler != nil {
+		if message, ok := evalDetails.FlagMetadata.deprecation(); ok {
+			runEvaluationCallback("deprecation handler", flag, func() {
+				c.deprecationHandler(flag, message)
+			})
+		}
+	}
+	if err != nil && c.evaluationErrorCallback != nil {
+		runEvaluationCallback("evaluation error callback", flag, func() {
+			c.evaluationErrorCallback(flag, err, errorCode(err))
+		})
+	}
+	if err != nil && errorCode(err) == TypeMismatchCode {
+		c.reportTypeMismatch(flag, flagType, err)
+	}
+	return evalDetails, err
+}
+
+func (c *Client) evaluateFlag(
+	ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx EvaluationContext, options EvaluationOptions,
+) (evalDetails InterfaceEvaluationDetails, err error) {
+	evalDetails = InterfaceEvaluationDetails{
 		Value: defaultValue,
 		EvaluationDetails: EvaluationDetails{
 			FlagKey:  flag,
@@ -688,19 +1534,42 @@ func (c *Client) evaluate(
 		},
 	}
 
+	var hookCtx HookContext
+	if c.auditSink != nil {
+		// runs last, once the finally hooks ran
+		defer func() {
+			runEvaluationCallback("audit sink", flag, func() {
+				c.auditSink(newAuditRecord(c.metadata.domain, hookCtx, evalDetails, err))
+			})
+		}()
+	}
+
 	if !utf8.Valid([]byte(flag)) {
 		return evalDetails, NewParseErrorResolutionError("flag key is not a UTF-8 encoded string")
 	}
 
 	// ensure that the same provider & hooks are used across this transaction to avoid unexpected behaviour
 	provider, globalHooks, globalCtx := c.api.ForEvaluation(c.metadata.domain)
+	if snapshot, ok := ctx.Value(snapshotKey{domain: c.metadata.domain}).(FeatureProvider); ok {
+		provider = snapshot
+	}
 
-	evalCtx = mergeContexts(evalCtx, c.evaluationContext, TransactionContext(ctx), globalCtx)                                  // API (global) -> transaction -> client -> invocation
-	apiClientInvocationProviderHooks := append(append(append(globalHooks, c.hooks...), options.hooks...), provider.Hooks()...) // API, Client, Invocation, Provider
-	providerInvocationClientApiHooks := append(append(append(provider.Hooks(), options.hooks...), c.hooks...), globalHooks...) // Provider, Invocation, Client, API
+	if options.exclusiveContext {
+		evalCtx = mergeContexts(evalCtx) // invocation only
+	} else {
+		evalCtx = c.mergeContextLevels(ctx, evalCtx, globalCtx) // API (global) -> transaction -> client -> invocation by default
+		evalCtx = withDefaultContext(provider, evalCtx)
+	}
+	if enricher, ok := provider.(ContextEnricher); ok {
+		evalCtx = enrichContext(ctx, enricher, evalCtx)
+	}
+	apiHooks := scopeHooks(APIHookScope, globalHooks)
+	clientHooks := concatHooks(scopeHooks(ClientHookScope, c.hooks), scopeHooks(ClientHookScope, c.typedHooks[flagType]))
+	invocationHooks := scopeHooks(InvocationHookScope, options.hooks)
+	apiClientInvocationProviderHooks := concatHooks(apiHooks, clientHooks, invocationHooks, scopeHooks(ProviderHookScope, provider.Hooks())) // API, Client, Invocation, Provider
+	providerInvocationClientApiHooks := concatHooks(scopeHooks(ProviderHookScope, provider.Hooks()), invocationHooks, clientHooks, apiHooks) // Provider, Invocation, Client, API
 
-	var err error
-	hookCtx := HookContext{
+	hookCtx = HookContext{
 		flagKey:           flag,
 		flagType:          flagType,
 		defaultValue:      defaultValue,
@@ -713,17 +1582,39 @@ func (c *Client) evaluate(
 		c.finallyHooks(ctx, hookCtx, providerInvocationClientApiHooks, options)
 	}()
 
-        // bypass short-circuit logic for the Noop provider; it is essentially stateless and a "special case"
-	if _, ok := provider.(NoopProvider); !ok {
+	_, noop := provider.(NoopProvider)
+
+	// fail closed if the caller requires a ready provider. The Noop provider stands in for a missing provider, so it
+	// does not qualify whatever its state.
+	if state := c.State(); options.requireProvider && (noop || state != ReadyState) {
+		code, cause := ProviderNotReadyCode, ProviderNotReadyError
+		problem := "no provider is set"
+		if !noop {
+			problem = fmt.Sprintf("provider is in %s state", state)
+			if state == FatalState {
+				code, cause = ProviderFatalCode, ProviderFatalError
+			}
+		}
+		err := fmt.Errorf("%w: %s: %w", ErrProviderRequired, problem, cause)
+		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
+		setStateErrorDetails(&evalDetails, code, err)
+		evalDetails.Value = nil
+		return evalDetails, err
+	}
+
+	// bypass short-circuit logic for the Noop provider; it is essentially stateless and a "special case"
+	if !noop {
 		// short circuit if provider is in NOT READY state
 		if c.State() == NotReadyState {
 			c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, ProviderNotReadyError, options)
+			setStateErrorDetails(&evalDetails, ProviderNotReadyCode, ProviderNotReadyError)
 			return evalDetails, ProviderNotReadyError
 		}
 
 		// short circuit if provider is in FATAL state
 		if c.State() == FatalState {
 			c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, ProviderFatalError, options)
+			setStateErrorDetails(&evalDetails, ProviderFatalCode, ProviderFatalError)
 			return evalDetails, ProviderFatalError
 		}
 	}
@@ -735,12 +1626,39 @@ func (c *Client) evaluate(
 		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
 		return evalDetails, err
 	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// the before hooks used up the deadline, leaving no budget for the provider. A canceled context is left for
+		// the provider to honor.
+		resolutionErr := NewGeneralResolutionError(
+			fmt.Sprintf("no time left to evaluate flag %q after before hooks", flag),
+		).WithCause(ctx.Err())
+		err = fmt.Errorf("error code: %w", resolutionErr)
+		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
+		evalDetails.ResolutionDetail = ProviderResolutionDetail{ResolutionError: resolutionErr, Reason: ErrorReason}.ResolutionDetail()
+		return evalDetails, err
+	}
+
+	if len(options.providerOptions) > 0 {
+		ctx = withProviderOptions(ctx, options.providerOptions)
+	}
+
+	flatCtx, resolutionErr := c.checkedFlattenContext(provider, flag, flagType, evalCtx)
+	if resolutionErr != nil {
+		err = fmt.Errorf("error code: %w", *resolutionErr)
+		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
+		evalDetails.ErrorCode = resolutionErr.code
+		evalDetails.ErrorMessage = resolutionErr.message
+		evalDetails.Reason = ErrorReason
+		return evalDetails, err
+	}
 
-	flatCtx := flattenContext(evalCtx)
 	var resolution InterfaceResolutionDetail
 	switch flagType {
 	case Object:
-		resolution = provider.ObjectEvaluation(ctx, flag, defaultValue, flatCtx)
+		resolution = provider.ObjectEvaluation(c.withSerializedDefault(ctx, flag, defaultValue), flag, defaultValue, flatCtx)
+		if c.parseJSONObjects && resolution.Error() == nil {
+			resolution = parseJSONObject(resolution, defaultValue)
+		}
 	case Boolean:
 		defValue := defaultValue.(bool)
 		res := provider.BooleanEvaluation(ctx, flag, defValue, flatCtx)
@@ -763,16 +1681,28 @@ func (c *Client) evaluate(
 		resolution.Value = res.Value
 	}
 
+	if resolution.Reason == DisabledReason {
+		// disabled is a normal state, not an error, even if the provider reports one along with the reason
+		resolution = InterfaceResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				Reason:       DisabledReason,
+				FlagMetadata: resolution.FlagMetadata,
+			},
+		}
+	}
+
 	err = resolution.Error()
 	if err != nil {
 		err = fmt.Errorf("error code: %w", err)
 		c.errorHooks(ctx, hookCtx, providerInvocationClientApiHooks, err, options)
 		evalDetails.ResolutionDetail = resolution.ResolutionDetail()
-		evalDetails.Reason = ErrorReason
+		evalDetails.Reason = errorReason(evalDetails.ErrorCode)
 		return evalDetails, err
 	}
 	evalDetails.Value = resolution.Value
 	evalDetails.ResolutionDetail = resolution.ResolutionDetail()
+	evalDetails.FlagMetadata = evalDetails.FlagMetadata.withValueAge(provider)
 
 	if err := c.afterHooks(ctx, hookCtx, providerInvocationClientApiHooks, evalDetails, options); err != nil {
 		err = fmt.Errorf("after hook: %w", err)
@@ -783,10 +1713,178 @@ func (c *Client) evaluate(
 	return evalDetails, nil
 }
 
+// checkedFlattenContext flattens the evaluation context given to the provider, applying the checks and policies
+// configured by the client options. A resolution error is returned if the evaluation must not reach the provider.
+func (c *Client) checkedFlattenContext(
+	provider FeatureProvider, flag string, flagType Type, evalCtx EvaluationContext,
+) (FlattenedContext, *ResolutionError) {
+	if c.validateFlagSchema {
+		if resolutionErr := validateFlagType(provider, flag, flagType); resolutionErr != nil {
+			return nil, resolutionErr
+		}
+	}
+
+	flatCtx := flattenContext(evalCtx)
+	if targetingKey, ok := flatCtx[TargetingKey]; ok && targetingKey == "" {
+		switch c.emptyTargetingKeyPolicy {
+		case OmitEmptyTargetingKey:
+			delete(flatCtx, TargetingKey)
+		case ErrorOnEmptyTargetingKey:
+			resolutionErr := NewInvalidContextResolutionError("targeting key is empty")
+			return nil, &resolutionErr
+		}
+	}
+
+	if c.maxContextAttributes > 0 {
+		if resolutionErr := c.limitContextAttributes(flag, flatCtx); resolutionErr != nil {
+			return nil, resolutionErr
+		}
+	}
+
+	return flatCtx, nil
+}
+
+// limitContextAttributes applies the maximum number of attributes to the flattened context, the targeting key aside.
+// Oversized contexts are either rejected with an INVALID_CONTEXT resolution error, or truncated to the attributes
+// coming first in key order.
+func (c *Client) limitContextAttributes(flag string, flatCtx FlattenedContext) *ResolutionError {
+	keys := make([]string, 0, len(flatCtx))
+	for key := range flatCtx {
+		if key != TargetingKey {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) <= c.maxContextAttributes {
+		return nil
+	}
+
+	if !c.truncateContextAttributes {
+		resolutionErr := NewInvalidContextResolutionError(
+			fmt.Sprintf("evaluation context holds %d attributes, exceeding the maximum of %d", len(keys), c.maxContextAttributes),
+		)
+		return &resolutionErr
+	}
+
+	slog.Warn("truncating oversized evaluation context",
+		"flag", flag, "attributes", len(keys), "max", c.maxContextAttributes)
+	sort.Strings(keys)
+	for _, key := range keys[c.maxContextAttributes:] {
+		delete(flatCtx, key)
+	}
+	return nil
+}
+
+// validateFlagType returns a TYPE_MISMATCH resolution error if the provider declares the flag with another type
+func validateFlagType(provider FeatureProvider, flag string, flagType Type) *ResolutionError {
+	schema, ok := provider.(FlagSchema)
+	if !ok {
+		return nil
+	}
+
+	declared, ok := schema.FlagSchema()[flag]
+	if !ok || declared == flagType {
+		return nil
+	}
+
+	resolutionErr := NewTypeMismatchResolutionError(
+		fmt.Sprintf("flag %s is declared as %s but was evaluated as %s", flag, declared, flagType),
+	)
+	return &resolutionErr
+}
+
+// reportTypeMismatch calls the type mismatch callback of clients with strict types
+func (c *Client) reportTypeMismatch(flag string, flagType Type, err error) {
+	if c.onTypeMismatch != nil {
+		c.onTypeMismatch(flag, flagType, err)
+	}
+}
+
+// providerDefault returns the default prescribed for the flag by the provider, if it is a DefaultOverrider and the
+// prescribed default is of the flag type. Otherwise, the caller's default is returned.
+func providerDefault[T any](c *Client, flag string, flagType Type, defaultValue T) T {
+	provider, _, _ := c.api.ForEvaluation(c.metadata.domain)
+	overrider, ok := provider.(DefaultOverrider)
+	if !ok {
+		return defaultValue
+	}
+
+	override, ok := overrider.DefaultFor(flag, flagType)
+	if !ok {
+		return defaultValue
+	}
+
+	if value, ok := override.(T); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// errorCode returns the error code of an evaluation error. Errors not originating from the resolution (e.g. hook
+// errors) have the GENERAL code.
+func errorCode(err error) ErrorCode {
+	var resolutionErr ResolutionError
+	switch {
+	case errors.Is(err, ProviderNotReadyError):
+		return ProviderNotReadyCode
+	case errors.Is(err, ProviderFatalError):
+		return ProviderFatalCode
+	case errors.As(err, &resolutionErr):
+		return resolutionErr.code
+	default:
+		return GeneralCode
+	}
+}
+
+// errorReason returns the reason reported for a failed resolution with the given error code. Degraded decisions due
+// to a missing targeting key keep a dedicated reason so they can be told apart from other errors.
+func errorReason(code ErrorCode) Reason {
+	if code == TargetingKeyMissingCode {
+		return TargetingKeyMissingReason
+	}
+	return ErrorReason
+}
+
+// parseJSONObject decodes the value of an object resolution if it is a JSON string, into a value of the type of the
+// default value if set. A PARSE_ERROR resolution is returned for invalid JSON.
+func parseJSONObject(resolution InterfaceResolutionDetail, defaultValue interface{}) InterfaceResolutionDetail {
+	raw, ok := resolution.Value.(string)
+	if !ok {
+		return resolution
+	}
+
+	var target interface{}
+	if defaultValue != nil {
+		target = reflect.New(reflect.TypeOf(defaultValue)).Interface()
+	} else {
+		target = new(interface{})
+	}
+
+	if err := json.Unmarshal([]byte(raw), target); err != nil {
+		resolution.Value = defaultValue
+		resolution.Reason = ErrorReason
+		resolution.ResolutionError = NewParseErrorResolutionError(
+			fmt.Sprintf("object flag value is not valid JSON: %v", err),
+		).WithCause(err)
+		return resolution
+	}
+
+	resolution.Value = reflect.ValueOf(target).Elem().Interface()
+	return resolution
+}
+
+// setStateErrorDetails fills the details of an evaluation short-circuited because of the provider state, so that
+// callers can tell it apart from other errors
+func setStateErrorDetails(evalDetails *InterfaceEvaluationDetails, code ErrorCode, err error) {
+	evalDetails.ErrorCode = code
+	evalDetails.ErrorMessage = err.Error()
+	evalDetails.Reason = ErrorReason
+}
+
 func flattenContext(evalCtx EvaluationContext) FlattenedContext {
-	flatCtx := FlattenedContext{}
-	if evalCtx.attributes != nil {
-		flatCtx = evalCtx.Attributes()
+	// size the map up front for the attributes and the targeting key, so it never grows while being filled
+	flatCtx := make(FlattenedContext, len(evalCtx.attributes)+1)
+	for key, value := range evalCtx.attributes {
+		flatCtx[key] = value
 	}
 	if evalCtx.targetingKey != "" {
 		flatCtx[TargetingKey] = evalCtx.targetingKey
@@ -794,11 +1892,36 @@ func flattenContext(evalCtx EvaluationContext) FlattenedContext {
 	return flatCtx
 }
 
+// enrichContext merges the enrichment of the given ContextEnricher into the evaluation context, at the lowest
+// precedence
+func enrichContext(ctx context.Context, enricher ContextEnricher, evalCtx EvaluationContext) EvaluationContext {
+	enriched := enricher.EnrichContext(ctx, flattenContext(evalCtx))
+	return mergeContexts(evalCtx, unflattenContext(enriched))
+}
+
+// unflattenContext is the inverse of flattenContext
+func unflattenContext(flatCtx FlattenedContext) EvaluationContext {
+	attributes := make(map[string]interface{}, len(flatCtx))
+	for key, value := range flatCtx {
+		attributes[key] = value
+	}
+
+	targetingKey, _ := attributes[TargetingKey].(string)
+	delete(attributes, TargetingKey)
+
+	return EvaluationContext{
+		targetingKey: targetingKey,
+		attributes:   attributes,
+	}
+}
+
 func (c *Client) beforeHooks(
-	ctx context.Context, hookCtx HookContext, hooks []Hook, evalCtx EvaluationContext, options EvaluationOptions,
+	ctx context.Context, hookCtx HookContext, hooks []scopedHook, evalCtx EvaluationContext, options EvaluationOptions,
 ) (EvaluationContext, error) {
 	for _, hook := range hooks {
+		start := time.Now()
 		resultEvalCtx, err := hook.Before(ctx, hookCtx, options.hookHints)
+		options.traceHook(hookCtx, BeforeHookStage, hook, start, err)
 		if resultEvalCtx != nil {
 			hookCtx.evaluationContext = *resultEvalCtx
 		}
@@ -811,10 +1934,13 @@ func (c *Client) beforeHooks(
 }
 
 func (c *Client) afterHooks(
-	ctx context.Context, hookCtx HookContext, hooks []Hook, evalDetails InterfaceEvaluationDetails, options EvaluationOptions,
+	ctx context.Context, hookCtx HookContext, hooks []scopedHook, evalDetails InterfaceEvaluationDetails, options EvaluationOptions,
 ) error {
 	for _, hook := range hooks {
-		if err := hook.After(ctx, hookCtx, evalDetails, options.hookHints); err != nil {
+		start := time.Now()
+		err := hook.After(ctx, hookCtx, evalDetails, options.hookHints)
+		options.traceHook(hookCtx, AfterHookStage, hook, start, err)
+		if err != nil {
 			return err
 		}
 	}
@@ -822,16 +1948,39 @@ func (c *Client) afterHooks(
 	return nil
 }
 
-func (c *Client) errorHooks(ctx context.Context, hookCtx HookContext, hooks []Hook, err error, options EvaluationOptions) {
+func (c *Client) errorHooks(ctx context.Context, hookCtx HookContext, hooks []scopedHook, err error, options EvaluationOptions) {
 	for _, hook := range hooks {
+		start := time.Now()
 		hook.Error(ctx, hookCtx, err, options.hookHints)
+		options.traceHook(hookCtx, ErrorHookStage, hook, start, nil)
 	}
 }
 
-func (c *Client) finallyHooks(ctx context.Context, hookCtx HookContext, hooks []Hook, options EvaluationOptions) {
+func (c *Client) finallyHooks(ctx context.Context, hookCtx HookContext, hooks []scopedHook, options EvaluationOptions) {
 	for _, hook := range hooks {
+		start := time.Now()
 		hook.Finally(ctx, hookCtx, options.hookHints)
+		options.traceHook(hookCtx, FinallyHookStage, hook, start, nil)
+	}
+}
+
+// failedValue returns the value of a failed evaluation: the default value, unless the evaluation failed closed as it
+// required a ready provider, see WithRequireProvider
+func failedValue[T any](err error, defaultValue T) T {
+	if errors.Is(err, ErrProviderRequired) {
+		var zero T
+		return zero
+	}
+	return defaultValue
+}
+
+// withDefaultContext merges the default context of the provider, if it is a DefaultContextSupplier, at the lowest
+// precedence
+func withDefaultContext(provider FeatureProvider, evalCtx EvaluationContext) EvaluationContext {
+	if supplier, ok := provider.(DefaultContextSupplier); ok {
+		return mergeContexts(evalCtx, supplier.DefaultContext())
 	}
+	return evalCtx
 }
 
 // merges attributes from the given EvaluationContexts with the nth EvaluationContext taking precedence in case
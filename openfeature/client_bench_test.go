@@ -0,0 +1,23 @@
+package openfeature
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkFlattenContext(b *testing.B) {
+	for _, size := range []int{0, 5, 50, 500} {
+		attributes := make(map[string]interface{}, size)
+		for i := 0; i < size; i++ {
+			attributes[fmt.Sprintf("attribute-%d", i)] = i
+		}
+		evalCtx := NewEvaluationContext("targeting-key", attributes)
+
+		b.Run(fmt.Sprintf("attributes=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				flattenContext(evalCtx)
+			}
+		})
+	}
+}
@@ -3,8 +3,10 @@ package openfeature
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -904,6 +906,92 @@ func TestTrack(t *testing.T) {
 	}
 }
 
+// panickingTracker is a provider whose Tracker implementation panics
+type panickingTracker struct {
+	NoopProvider
+}
+
+func (p panickingTracker) Track(context.Context, string, EvaluationContext, TrackingEventDetails) {
+	panic("tracker failure")
+}
+
+func TestTrackRecoversFromTrackerPanic(t *testing.T) {
+	t.Run("default handler swallows the panic", func(t *testing.T) {
+		client := NewClient("test-client")
+		client.api = newEvaluationAPI(newEventExecutor())
+		_ = client.api.SetProviderAndWait(panickingTracker{})
+
+		client.Track(context.Background(), "example-event", EvaluationContext{}, TrackingEventDetails{})
+	})
+
+	t.Run("configured handler receives the panic", func(t *testing.T) {
+		var gotEvent string
+		var gotPanic interface{}
+		client := NewClient("test-client", WithTrackPanicHandler(func(trackingEventName string, recovered interface{}) {
+			gotEvent = trackingEventName
+			gotPanic = recovered
+		}))
+		client.api = newEvaluationAPI(newEventExecutor())
+		_ = client.api.SetProviderAndWait(panickingTracker{})
+
+		client.Track(context.Background(), "example-event", EvaluationContext{}, TrackingEventDetails{})
+
+		if gotEvent != "example-event" {
+			t.Errorf("expected handler to receive event name %q, got %q", "example-event", gotEvent)
+		}
+		if gotPanic != "tracker failure" {
+			t.Errorf("expected handler to receive the recovered panic, got %v", gotPanic)
+		}
+	})
+}
+
+// contextCapturingTracker is a provider whose Tracker implementation captures the context.Context it receives
+type contextCapturingTracker struct {
+	NoopProvider
+	captured chan context.Context
+}
+
+func (p contextCapturingTracker) Track(ctx context.Context, _ string, _ EvaluationContext, _ TrackingEventDetails) {
+	p.captured <- ctx
+}
+
+func TestTrackPropagatesContext(t *testing.T) {
+	type ctxKey struct{}
+
+	tests := map[string]func(tracker contextCapturingTracker) FeatureProvider{
+		"tracker": func(tracker contextCapturingTracker) FeatureProvider {
+			return tracker
+		},
+		"decorated tracker": func(tracker contextCapturingTracker) FeatureProvider {
+			return NewOverrideProvider(tracker)
+		},
+	}
+
+	for name, provider := range tests {
+		t.Run(name, func(t *testing.T) {
+			tracker := contextCapturingTracker{captured: make(chan context.Context, 1)}
+			executor := newEventExecutor()
+			api := newEvaluationAPI(executor)
+			if err := api.SetProviderAndWait(provider(tracker)); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+			client := newClient(t.Name(), api, executor)
+
+			ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+			cancel()
+			client.Track(ctx, "example-event", EvaluationContext{}, TrackingEventDetails{})
+
+			got := <-tracker.captured
+			if got.Value(ctxKey{}) != "value" {
+				t.Error("expected the tracker to receive the context of the caller")
+			}
+			if !errors.Is(got.Err(), context.Canceled) {
+				t.Errorf("expected the cancellation to be observable by the tracker, got %v", got.Err())
+			}
+		})
+	}
+}
+
 func TestFlattenContext(t *testing.T) {
 	tests := map[string]struct {
 		inCtx  EvaluationContext
@@ -981,6 +1069,106 @@ func TestFlattenContext(t *testing.T) {
 	}
 }
 
+func TestWithExclusiveContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+
+	executor := newEventExecutor()
+	client := newClient("test-client", newEvaluationAPI(executor), executor)
+	_ = client.api.SetProviderAndWait(mockProvider)
+
+	client.api.SetEvaluationContext(NewEvaluationContext("api", map[string]interface{}{"api": "api", "shared": "api"}))
+	client.SetEvaluationContext(NewTargetlessEvaluationContext(map[string]interface{}{"client": "client"}))
+	ctx := WithTransactionContext(context.Background(), NewTargetlessEvaluationContext(map[string]interface{}{"txn": "txn"}))
+	invocationCtx := NewTargetlessEvaluationContext(map[string]interface{}{"shared": "invocation"})
+
+	t.Run("merges all contexts by default", func(t *testing.T) {
+		mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, FlattenedContext{
+			TargetingKey: "api",
+			"api":        "api",
+			"txn":        "txn",
+			"client":     "client",
+			"shared":     "invocation",
+		})
+
+		_, _ = client.BooleanValue(ctx, "flag", false, invocationCtx)
+	})
+
+	t.Run("uses only the invocation context", func(t *testing.T) {
+		mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "flag", false, FlattenedContext{
+			"shared": "invocation",
+		})
+
+		_, _ = client.BooleanValue(ctx, "flag", false, invocationCtx, WithExclusiveContext())
+	})
+}
+
+// enrichingProvider is a provider implementing ContextEnricher, recording the context it resolves with
+type enrichingProvider struct {
+	NoopProvider
+	resolvedCtx *FlattenedContext
+}
+
+func (p enrichingProvider) EnrichContext(_ context.Context, flat FlattenedContext) FlattenedContext {
+	enriched := FlattenedContext{}
+	for key, value := range flat {
+		enriched[key] = value
+	}
+	enriched["geo"] = "NL"
+	enriched["user"] = "enriched"
+	return enriched
+}
+
+func (p enrichingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	*p.resolvedCtx = evalCtx
+	return p.NoopProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// contextCapturingHook records the evaluation context seen by before hooks
+type contextCapturingHook struct {
+	UnimplementedHook
+	seen *EvaluationContext
+}
+
+func (h contextCapturingHook) Before(_ context.Context, hookContext HookContext, _ HookHints) (*EvaluationContext, error) {
+	*h.seen = hookContext.EvaluationContext()
+	return nil, nil
+}
+
+func TestContextEnricher(t *testing.T) {
+	var resolvedCtx FlattenedContext
+	var hookCtx EvaluationContext
+
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(enrichingProvider{resolvedCtx: &resolvedCtx}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	evalCtx := NewEvaluationContext("key", map[string]interface{}{"user": "invocation"})
+	_, err := client.BooleanValue(context.Background(), "flag", false, evalCtx,
+		WithHooks(contextCapturingHook{seen: &hookCtx}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := FlattenedContext{
+		TargetingKey: "key",
+		"geo":        "NL",
+		"user":       "invocation",
+	}
+	if !reflect.DeepEqual(expected, resolvedCtx) {
+		t.Errorf("expected provider to resolve with %v, got %v", expected, resolvedCtx)
+	}
+
+	if hookCtx.Attribute("geo") != "NL" {
+		t.Errorf("expected enriched attribute to be visible to hooks, got %v", hookCtx.Attributes())
+	}
+}
+
 // TestBeforeHookNilContext asserts that when a Before hook returns a nil EvaluationContext it doesn't overwrite the
 // existing EvaluationContext
 func TestBeforeHookNilContext(t *testing.T) {
@@ -1050,6 +1238,97 @@ func TestErrorCodeFromProviderReturnedInEvaluationDetails(t *testing.T) {
 	}
 }
 
+func TestTargetingKeyMissingReasonPreserved(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+
+	err := SetNamedProviderAndWait(t.Name(), mockProvider)
+	if err != nil {
+		t.Errorf("error setting up provider %v", err)
+	}
+
+	mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(BoolResolutionDetail{
+			Value: true,
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewTargetingKeyMissingResolutionError("no targeting key"),
+			},
+		})
+
+	client := GetApiInstance().GetNamedClient(t.Name())
+	evalDetails, err := client.BooleanValueDetails(context.Background(), "foo", false, EvaluationContext{})
+	if err == nil {
+		t.Error("expected err, got nil")
+	}
+
+	if evalDetails.Value != false {
+		t.Errorf("expected default value, got %v", evalDetails.Value)
+	}
+
+	if evalDetails.ErrorCode != TargetingKeyMissingCode {
+		t.Errorf("expected error code %s, got %s", TargetingKeyMissingCode, evalDetails.ErrorCode)
+	}
+
+	if evalDetails.Reason != TargetingKeyMissingReason {
+		t.Errorf("expected reason %s, got %s", TargetingKeyMissingReason, evalDetails.Reason)
+	}
+}
+
+// providerHTTPError is a rich, provider specific error
+type providerHTTPError struct {
+	statusCode int
+}
+
+func (e *providerHTTPError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.statusCode)
+}
+
+func TestErrorCauseFromProviderReturnedInEvaluationDetails(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+	ctrl := gomock.NewController(t)
+
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+
+	err := SetNamedProviderAndWait(t.Name(), mockProvider)
+	if err != nil {
+		t.Errorf("error setting up provider %v", err)
+	}
+
+	cause := &providerHTTPError{statusCode: 503}
+	mockProvider.EXPECT().StringEvaluation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(StringResolutionDetail{
+			Value: "default",
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewGeneralResolutionError("backend unavailable").WithCause(cause),
+			},
+		})
+
+	client := GetApiInstance().GetNamedClient(t.Name())
+	evalDetails, err := client.StringValueDetails(context.Background(), "foo", "default", EvaluationContext{})
+	if err == nil {
+		t.Fatal("expected err, got nil")
+	}
+
+	var httpErr *providerHTTPError
+	if !errors.As(evalDetails.ErrorCause, &httpErr) || httpErr.statusCode != 503 {
+		t.Errorf("expected evaluation details to carry the provider error cause, got %v", evalDetails.ErrorCause)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("expected returned error to wrap the provider error cause, got %v", err)
+	}
+
+	if evalDetails.ErrorMessage != "backend unavailable" {
+		t.Errorf("expected error message %q, got %q", "backend unavailable", evalDetails.ErrorMessage)
+	}
+}
+
 func TestSwitchingProvidersMidEvaluationCausesNoImpactToEvaluation(t *testing.T) {
 	defer t.Cleanup(initSingleton)
 	ctrl := gomock.NewController(t)
@@ -1223,6 +1502,42 @@ func TestFlagMetadataAccessors(t *testing.T) {
 		}
 	})
 
+	t.Run("unsigned int", func(t *testing.T) {
+		metadata := FlagMetadata{
+			"uint":     uint(12),
+			"uint8":    uint8(12),
+			"uint16":   uint16(12),
+			"uint32":   uint32(12),
+			"uint64":   uint64(12),
+			"int":      int(12),
+			"overflow": uint64(math.MaxUint64),
+			"negative": int(-1),
+		}
+		for _, k := range []string{"uint", "uint8", "uint16", "uint32", "uint64", "int"} {
+			u, err := metadata.GetUint(k)
+			if err != nil || u != 12 {
+				t.Errorf("unexpected uint value for %s: %d, %v", k, u, err)
+			}
+			i, err := metadata.GetInt(k)
+			if err != nil || i != 12 {
+				t.Errorf("unexpected int value for %s: %d, %v", k, i, err)
+			}
+		}
+
+		if u, err := metadata.GetUint("overflow"); err != nil || u != math.MaxUint64 {
+			t.Errorf("unexpected uint value: %d, %v", u, err)
+		}
+		if _, err := metadata.GetInt("overflow"); err == nil {
+			t.Error("expected an error for a value overflowing int64")
+		}
+		if _, err := metadata.GetUint("negative"); err == nil {
+			t.Error("expected an error for a negative value")
+		}
+		if _, err := metadata.GetUint("not-in-map"); err == nil {
+			t.Error("expected an error for a missing key")
+		}
+	})
+
 	t.Run("float", func(t *testing.T) {
 		expectedValue := float64(12)
 		metadata := FlagMetadata{
@@ -1527,3 +1842,1379 @@ func TestRequirement_5_3_5(t *testing.T) {
 	}, time.Second, 100*time.Millisecond, "expected client to report FATAL state")
 
 }
+
+// snapshottingProvider resolves boolean flags to its current configuration, which can be pinned with Snapshot
+type snapshottingProvider struct {
+	NoopProvider
+	enabled *bool
+}
+
+func (p snapshottingProvider) BooleanEvaluation(_ context.Context, _ string, _ bool, _ FlattenedContext) BoolResolutionDetail {
+	return BoolResolutionDetail{
+		Value:                    *p.enabled,
+		ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason},
+	}
+}
+
+func (p snapshottingProvider) Snapshot(_ context.Context) (FeatureProvider, error) {
+	pinned := *p.enabled
+	return snapshottingProvider{enabled: &pinned}, nil
+}
+
+func TestClientSnapshot(t *testing.T) {
+	enabled := true
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(snapshottingProvider{enabled: &enabled}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	ctx, err := client.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// configuration change after the snapshot
+	enabled = false
+
+	value, err := client.BooleanValue(ctx, "flag", false, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !value {
+		t.Error("expected evaluation with the snapshot context to resolve from the snapshot")
+	}
+
+	value, err = client.BooleanValue(context.Background(), "flag", true, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value {
+		t.Error("expected evaluation without the snapshot context to resolve from the current configuration")
+	}
+}
+
+func TestClientSnapshotWithoutSnapshotter(t *testing.T) {
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+
+	ctx := context.Background()
+	snapshotCtx, err := client.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snapshotCtx != ctx {
+		t.Error("expected the context to be returned unchanged")
+	}
+}
+
+func TestWithEvaluationErrorCallback(t *testing.T) {
+	type callbackCall struct {
+		flagKey string
+		code    ErrorCode
+	}
+
+	var calls []callbackCall
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor,
+		WithEvaluationErrorCallback(func(flagKey string, err error, code ErrorCode) {
+			if err == nil {
+				t.Error("expected the evaluation error to be passed to the callback")
+			}
+			calls = append(calls, callbackCall{flagKey: flagKey, code: code})
+		}),
+	)
+
+	// the provider is not ready yet
+	ctrl := gomock.NewController(t)
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+	if err := client.api.SetProvider(struct {
+		FeatureProvider
+		StateHandler
+	}{mockProvider, &stateHandlerForTests{initF: func(e EvaluationContext) error {
+		time.Sleep(time.Second)
+		return nil
+	}}}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	_, _ = client.BooleanValue(context.Background(), "not-ready", false, EvaluationContext{})
+
+	if err := client.api.SetProviderAndWait(mockProvider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "missing", gomock.Any(), gomock.Any()).
+		Return(BoolResolutionDetail{
+			ProviderResolutionDetail: ProviderResolutionDetail{
+				ResolutionError: NewFlagNotFoundResolutionError("not found"),
+			},
+		})
+	_, _ = client.BooleanValue(context.Background(), "missing", false, EvaluationContext{})
+
+	mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "found", gomock.Any(), gomock.Any()).
+		Return(BoolResolutionDetail{Value: true})
+	_, _ = client.BooleanValue(context.Background(), "found", false, EvaluationContext{})
+
+	expected := []callbackCall{
+		{flagKey: "not-ready", code: ProviderNotReadyCode},
+		{flagKey: "missing", code: FlagNotFoundCode},
+	}
+	if !reflect.DeepEqual(expected, calls) {
+		t.Errorf("expected callback calls %v, got %v", expected, calls)
+	}
+}
+
+func TestClientResolveContext(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	client := newClient(t.Name(), api, executor)
+	if err := api.SetProviderAndWait(enrichingProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	api.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"api": true, "level": "api"}))
+	client.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"client": true, "level": "client"}))
+	ctx := WithTransactionContext(context.Background(), NewEvaluationContext("", map[string]interface{}{"transaction": true, "level": "transaction"}))
+
+	flatCtx := client.ResolveContext(ctx, NewEvaluationContext("key", map[string]interface{}{"level": "invocation"}))
+
+	expected := FlattenedContext{
+		TargetingKey:  "key",
+		"api":         true,
+		"transaction": true,
+		"client":      true,
+		"level":       "invocation",
+		"geo":         "NL",
+		"user":        "enriched",
+	}
+	if !reflect.DeepEqual(expected, flatCtx) {
+		t.Errorf("expected %v, got %v", expected, flatCtx)
+	}
+}
+
+func TestClientResolveContextConcurrentWrites(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	client := newClient(t.Name(), api, executor)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			client.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"i": i}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			client.ResolveContext(context.Background(), EvaluationContext{})
+		}
+	}()
+	wg.Wait()
+}
+
+// defaultOverridingProvider prescribes the defaults of boolean and string flags. String flags are not found.
+type defaultOverridingProvider struct {
+	NoopProvider
+}
+
+func (p defaultOverridingProvider) DefaultFor(flagKey string, flagType Type) (interface{}, bool) {
+	switch {
+	case flagKey == "overridden" && flagType == Boolean:
+		return true, true
+	case flagKey == "overridden" && flagType == String:
+		return "prescribed", true
+	case flagKey == "mistyped":
+		return "not a boolean", true
+	default:
+		return nil, false
+	}
+}
+
+func (p defaultOverridingProvider) StringEvaluation(_ context.Context, _ string, defaultValue string, _ FlattenedContext) StringResolutionDetail {
+	return StringResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewFlagNotFoundResolutionError("not found"),
+			Reason:          ErrorReason,
+		},
+	}
+}
+
+func TestDefaultOverrider(t *testing.T) {
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(defaultOverridingProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	ctx := context.Background()
+
+	if value, _ := client.BooleanValue(ctx, "overridden", false, EvaluationContext{}); !value {
+		t.Error("expected the provider default to replace the caller default")
+	}
+
+	if value, err := client.StringValue(ctx, "overridden", "caller", EvaluationContext{}); err == nil || value != "prescribed" {
+		t.Errorf("expected the provider default to be returned along with the error, got %q, %v", value, err)
+	}
+
+	if value, _ := client.BooleanValue(ctx, "mistyped", false, EvaluationContext{}); value {
+		t.Error("expected a provider default of another type to be ignored")
+	}
+
+	if value, _ := client.BooleanValue(ctx, "other", false, EvaluationContext{}); value {
+		t.Error("expected the caller default when the provider prescribes none")
+	}
+}
+
+func TestClientClone(t *testing.T) {
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	var originalCount, cloneCount int
+	client.AddHooks(countingHook{count: &originalCount})
+	client.SetEvaluationContext(NewEvaluationContext("original", nil))
+
+	clone := client.Clone()
+	clone.AddHooks(countingHook{count: &cloneCount})
+	clone.AddHooksForType(Boolean, countingHook{count: &cloneCount})
+	clone.SetEvaluationContext(NewEvaluationContext("clone", nil))
+
+	if clone.Metadata().Domain() != client.Metadata().Domain() {
+		t.Errorf("expected the clone to be bound to domain %s, got %s", client.Metadata().Domain(), clone.Metadata().Domain())
+	}
+	if client.EvaluationContext().TargetingKey() != "original" {
+		t.Errorf("expected the original evaluation context to be unchanged, got %v", client.EvaluationContext())
+	}
+
+	_, _ = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+	if originalCount != 1 || cloneCount != 0 {
+		t.Errorf("expected only the original hooks to run for the original client, got %d and %d", originalCount, cloneCount)
+	}
+
+	_, _ = clone.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+	if originalCount != 2 || cloneCount != 2 {
+		t.Errorf("expected the copied and added hooks to run for the clone, got %d and %d", originalCount, cloneCount)
+	}
+}
+
+type schemaProvider struct {
+	NoopProvider
+}
+
+func (p schemaProvider) FlagSchema() map[string]Type {
+	return map[string]Type{"flag": String}
+}
+
+func TestWithFlagSchemaValidation(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetProviderAndWait(schemaProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	t.Run("mismatching type", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithFlagSchemaValidation())
+		details, err := client.BooleanValueDetails(context.Background(), "flag", true, EvaluationContext{})
+		if err == nil {
+			t.Fatal("expected a type mismatch error")
+		}
+		if details.ErrorCode != TypeMismatchCode {
+			t.Errorf("expected error code %s, got %s", TypeMismatchCode, details.ErrorCode)
+		}
+		if details.Reason != ErrorReason {
+			t.Errorf("expected reason %s, got %s", ErrorReason, details.Reason)
+		}
+		if !details.Value {
+			t.Error("expected the default value to be returned")
+		}
+	})
+
+	t.Run("matching type", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithFlagSchemaValidation())
+		if _, err := client.StringValue(context.Background(), "flag", "default", EvaluationContext{}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("flag not in schema", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithFlagSchemaValidation())
+		if _, err := client.BooleanValue(context.Background(), "other", true, EvaluationContext{}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("validation disabled", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor)
+		if _, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+// flatContextCapturingProvider records the flattened context of boolean evaluations
+type flatContextCapturingProvider struct {
+	NoopProvider
+	resolvedCtx *FlattenedContext
+}
+
+func (p flatContextCapturingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	*p.resolvedCtx = evalCtx
+	return p.NoopProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func TestWithEmptyTargetingKeyPolicy(t *testing.T) {
+	evalCtx := NewTargetlessEvaluationContext(map[string]interface{}{TargetingKey: "", "plan": "pro"})
+
+	tests := map[string]struct {
+		policy          EmptyTargetingKeyPolicy
+		wantKey         bool
+		wantErrorCode   ErrorCode
+		wantProviderRun bool
+	}{
+		"include": {policy: IncludeEmptyTargetingKey, wantKey: true, wantProviderRun: true},
+		"omit":    {policy: OmitEmptyTargetingKey, wantKey: false, wantProviderRun: true},
+		"error":   {policy: ErrorOnEmptyTargetingKey, wantErrorCode: InvalidContextCode},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resolvedCtx FlattenedContext
+			executor := newEventExecutor()
+			client := newClient(t.Name(), newEvaluationAPI(executor), executor, WithEmptyTargetingKeyPolicy(test.policy))
+			if err := client.api.SetProviderAndWait(flatContextCapturingProvider{resolvedCtx: &resolvedCtx}); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+
+			details, err := client.BooleanValueDetails(context.Background(), "flag", false, evalCtx)
+			if details.ErrorCode != test.wantErrorCode {
+				t.Errorf("expected error code %q, got %q (%v)", test.wantErrorCode, details.ErrorCode, err)
+			}
+			if (resolvedCtx != nil) != test.wantProviderRun {
+				t.Fatalf("expected provider to be called: %t", test.wantProviderRun)
+			}
+			if !test.wantProviderRun {
+				return
+			}
+			if _, ok := resolvedCtx[TargetingKey]; ok != test.wantKey {
+				t.Errorf("expected targeting key in the flattened context: %t, got %v", test.wantKey, resolvedCtx)
+			}
+			if resolvedCtx["plan"] != "pro" {
+				t.Errorf("expected attributes to be passed through, got %v", resolvedCtx)
+			}
+		})
+	}
+}
+
+func TestWithMaxContextAttributes(t *testing.T) {
+	evalCtx := NewEvaluationContext("user", map[string]interface{}{"a": 1, "b": 2, "c": 3})
+
+	tests := map[string]struct {
+		option        ClientOption
+		wantErrorCode ErrorCode
+		wantCtx       FlattenedContext
+	}{
+		"within limit": {
+			option:  WithMaxContextAttributes(3),
+			wantCtx: FlattenedContext{TargetingKey: "user", "a": 1, "b": 2, "c": 3},
+		},
+		"exceeding limit": {
+			option:        WithMaxContextAttributes(2),
+			wantErrorCode: InvalidContextCode,
+		},
+		"truncated": {
+			option:  WithTruncatedContextAttributes(2),
+			wantCtx: FlattenedContext{TargetingKey: "user", "a": 1, "b": 2},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resolvedCtx FlattenedContext
+			executor := newEventExecutor()
+			client := newClient(t.Name(), newEvaluationAPI(executor), executor, test.option)
+			if err := client.api.SetProviderAndWait(flatContextCapturingProvider{resolvedCtx: &resolvedCtx}); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+
+			details, err := client.BooleanValueDetails(context.Background(), "flag", false, evalCtx)
+			if details.ErrorCode != test.wantErrorCode {
+				t.Errorf("expected error code %q, got %q (%v)", test.wantErrorCode, details.ErrorCode, err)
+			}
+			if !reflect.DeepEqual(resolvedCtx, test.wantCtx) {
+				t.Errorf("expected provider context %v, got %v", test.wantCtx, resolvedCtx)
+			}
+		})
+	}
+}
+
+// evaluations short-circuited because of the provider state must report a dedicated error code, whatever the type of
+// the flag
+func TestProviderStateErrorDetails(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	tests := map[string]struct {
+		initF     func(e EvaluationContext) error
+		wantState State
+		wantCode  ErrorCode
+		wantErr   error
+	}{
+		"not ready": {
+			initF: func(e EvaluationContext) error {
+				<-release
+				return nil
+			},
+			wantState: NotReadyState,
+			wantCode:  ProviderNotReadyCode,
+			wantErr:   ProviderNotReadyError,
+		},
+		"fatal": {
+			initF: func(e EvaluationContext) error {
+				return &ProviderInitError{ErrorCode: ProviderFatalCode}
+			},
+			wantState: FatalState,
+			wantCode:  ProviderFatalCode,
+			wantErr:   ProviderFatalError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			executor := newEventExecutor()
+			api := newEvaluationAPI(executor)
+			provider := struct {
+				FeatureProvider
+				StateHandler
+			}{
+				NoopProvider{},
+				&stateHandlerForTests{initF: test.initF},
+			}
+			_ = api.SetProvider(provider)
+			eventually(t, func() bool {
+				return executor.State(defaultDomain) == test.wantState
+			}, time.Second, 10*time.Millisecond, "provider did not reach the expected state")
+
+			client := newClient(t.Name(), api, executor)
+			ctx := context.Background()
+
+			boolDetails, boolErr := client.BooleanValueDetails(ctx, "flag", true, EvaluationContext{})
+			stringDetails, stringErr := client.StringValueDetails(ctx, "flag", "", EvaluationContext{})
+			floatDetails, floatErr := client.FloatValueDetails(ctx, "flag", 0, EvaluationContext{})
+			intDetails, intErr := client.IntValueDetails(ctx, "flag", 0, EvaluationContext{})
+			objectDetails, objectErr := client.ObjectValueDetails(ctx, "flag", nil, EvaluationContext{})
+
+			evaluations := map[Type]struct {
+				details EvaluationDetails
+				err     error
+			}{
+				Boolean: {boolDetails.EvaluationDetails, boolErr},
+				String:  {stringDetails.EvaluationDetails, stringErr},
+				Float:   {floatDetails.EvaluationDetails, floatErr},
+				Int:     {intDetails.EvaluationDetails, intErr},
+				Object:  {objectDetails.EvaluationDetails, objectErr},
+			}
+			for flagType, evaluation := range evaluations {
+				if !errors.Is(evaluation.err, test.wantErr) {
+					t.Errorf("%s: expected error %v, got %v", flagType, test.wantErr, evaluation.err)
+				}
+				if evaluation.details.ErrorCode != test.wantCode {
+					t.Errorf("%s: expected error code %s, got %s", flagType, test.wantCode, evaluation.details.ErrorCode)
+				}
+				if evaluation.details.Reason != ErrorReason {
+					t.Errorf("%s: expected reason %s, got %s", flagType, ErrorReason, evaluation.details.Reason)
+				}
+			}
+		})
+	}
+}
+
+// jsonStringProvider resolves object flags to the JSON string held by the "json" attribute of the context
+type jsonStringProvider struct {
+	NoopProvider
+}
+
+func (p jsonStringProvider) ObjectEvaluation(_ context.Context, _ string, _ interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	return InterfaceResolutionDetail{
+		Value:                    evalCtx["json"],
+		ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason},
+	}
+}
+
+func TestWithJSONObjectParsing(t *testing.T) {
+	type settings struct {
+		Theme string `json:"theme"`
+	}
+
+	tests := map[string]struct {
+		raw          string
+		defaultValue interface{}
+		want         interface{}
+		wantCode     ErrorCode
+	}{
+		"generic": {
+			raw:  `{"theme":"dark","sizes":[1,2]}`,
+			want: map[string]interface{}{"theme": "dark", "sizes": []interface{}{1.0, 2.0}},
+		},
+		"typed by default value": {
+			raw:          `{"theme":"dark"}`,
+			defaultValue: settings{Theme: "light"},
+			want:         settings{Theme: "dark"},
+		},
+		"invalid json": {
+			raw:          `{"theme":`,
+			defaultValue: settings{Theme: "light"},
+			want:         settings{Theme: "light"},
+			wantCode:     ParseErrorCode,
+		},
+	}
+
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetProviderAndWait(jsonStringProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t.Name(), api, executor, WithJSONObjectParsing())
+			evalCtx := NewTargetlessEvaluationContext(map[string]interface{}{"json": test.raw})
+
+			details, err := client.ObjectValueDetails(context.Background(), "flag", test.defaultValue, evalCtx)
+			if details.ErrorCode != test.wantCode {
+				t.Errorf("expected error code %q, got %q (%v)", test.wantCode, details.ErrorCode, err)
+			}
+			if !reflect.DeepEqual(details.Value, test.want) {
+				t.Errorf("expected value %#v, got %#v", test.want, details.Value)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor)
+		evalCtx := NewTargetlessEvaluationContext(map[string]interface{}{"json": `{"theme":"dark"}`})
+
+		value, err := client.ObjectValue(context.Background(), "flag", nil, evalCtx)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if value != `{"theme":"dark"}` {
+			t.Errorf("expected the raw string, got %#v", value)
+		}
+	})
+}
+
+// mismatchingProvider reports a type mismatch for every boolean flag
+type mismatchingProvider struct {
+	NoopProvider
+}
+
+func (p mismatchingProvider) BooleanEvaluation(_ context.Context, _ string, defaultValue bool, _ FlattenedContext) BoolResolutionDetail {
+	return BoolResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewTypeMismatchResolutionError("flag is a string"),
+			Reason:          ErrorReason,
+		},
+	}
+}
+
+func TestWithStrictTypes(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetProviderAndWait(mismatchingProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	t.Run("mismatch callback", func(t *testing.T) {
+		var mismatches []string
+		client := newClient(t.Name(), api, executor, WithStrictTypes(func(flagKey string, flagType Type, err error) {
+			mismatches = append(mismatches, fmt.Sprintf("%s:%s", flagKey, flagType))
+		}))
+
+		value, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{})
+		if err == nil || !value {
+			t.Errorf("expected the default value with an error, got %t and %v", value, err)
+		}
+		if _, err := client.StringValue(context.Background(), "flag", "", EvaluationContext{}); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+
+		if want := []string{"flag:bool"}; !reflect.DeepEqual(mismatches, want) {
+			t.Errorf("expected mismatches %v, got %v", want, mismatches)
+		}
+	})
+
+	t.Run("panics without callback", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithStrictTypes(nil))
+
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the type mismatch to panic")
+			}
+		}()
+		_, _ = client.BooleanValue(context.Background(), "flag", true, EvaluationContext{})
+	})
+
+	t.Run("silent by default", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor)
+		if _, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestWithResultTransformer(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		if flag == "missing" {
+			return nil, ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("missing"), Reason: ErrorReason}
+		}
+		return int64(150), ProviderResolutionDetail{Reason: StaticReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	clamp := func(details InterfaceEvaluationDetails) InterfaceEvaluationDetails {
+		if value, ok := details.Value.(int64); ok && value > 100 {
+			details.Value = int64(100)
+		}
+		return details
+	}
+
+	t.Run("transforms the returned value", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithResultTransformer(clamp))
+
+		details, err := client.IntValueDetails(context.Background(), "retries", 1, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if details.Value != 100 || details.Reason != StaticReason {
+			t.Errorf("expected the clamped value, got %+v", details)
+		}
+	})
+
+	t.Run("failed evaluations are not transformed", func(t *testing.T) {
+		var calls int
+		client := newClient(t.Name(), api, executor, WithResultTransformer(func(details InterfaceEvaluationDetails) InterfaceEvaluationDetails {
+			calls++
+			return details
+		}))
+
+		if _, err := client.IntValue(context.Background(), "missing", 1, EvaluationContext{}); err == nil {
+			t.Error("expected an error")
+		}
+		if calls != 0 {
+			t.Errorf("expected the transformer not to be called, got %d calls", calls)
+		}
+	})
+
+	t.Run("transformed values of another type are a type mismatch", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor, WithResultTransformer(func(details InterfaceEvaluationDetails) InterfaceEvaluationDetails {
+			details.Value = "150"
+			return details
+		}))
+
+		details, err := client.IntValueDetails(context.Background(), "retries", 1, EvaluationContext{})
+		if err == nil || details.ErrorCode != TypeMismatchCode || details.Value != 1 {
+			t.Errorf("expected a type mismatch with the default value, got %+v (%v)", details, err)
+		}
+	})
+}
+
+func TestValueOrNotFound(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		switch flag {
+		case "missing":
+			return nil, ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("missing"), Reason: ErrorReason}
+		case "broken":
+			return nil, ProviderResolutionDetail{ResolutionError: NewGeneralResolutionError("broken"), Reason: ErrorReason}
+		}
+		values := map[Type]interface{}{Boolean: true, String: "on", Float: 1.5, Int: int64(3), Object: "object"}
+		return values[flagType], ProviderResolutionDetail{Reason: StaticReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		flag      string
+		wantFound bool
+		wantErr   bool
+	}{
+		"found":     {flag: "flag", wantFound: true},
+		"not found": {flag: "missing", wantFound: false},
+		"errored":   {flag: "broken", wantFound: true, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			check := func(flagType Type, value, defaultValue, resolved interface{}, found bool, err error) {
+				t.Helper()
+				if found != test.wantFound || (err != nil) != test.wantErr {
+					t.Errorf("%s: expected found %t and error %t, got %t and %v", flagType, test.wantFound, test.wantErr, found, err)
+				}
+				want := resolved
+				if !test.wantFound || test.wantErr {
+					want = defaultValue
+				}
+				if value != want {
+					t.Errorf("%s: expected value %v, got %v", flagType, want, value)
+				}
+			}
+
+			boolValue, found, err := client.BooleanValueOrNotFound(ctx, test.flag, false, EvaluationContext{})
+			check(Boolean, boolValue, false, true, found, err)
+			stringValue, found, err := client.StringValueOrNotFound(ctx, test.flag, "off", EvaluationContext{})
+			check(String, stringValue, "off", "on", found, err)
+			floatValue, found, err := client.FloatValueOrNotFound(ctx, test.flag, 0.5, EvaluationContext{})
+			check(Float, floatValue, 0.5, 1.5, found, err)
+			intValue, found, err := client.IntValueOrNotFound(ctx, test.flag, 1, EvaluationContext{})
+			check(Int, intValue, int64(1), int64(3), found, err)
+			objectValue, found, err := client.ObjectValueOrNotFound(ctx, test.flag, "default", EvaluationContext{})
+			check(Object, objectValue, "default", "object", found, err)
+		})
+	}
+}
+
+func TestVariant(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		switch flag {
+		case "missing":
+			return nil, ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("missing"), Reason: ErrorReason}
+		case "variantless":
+			return true, ProviderResolutionDetail{Reason: StaticReason}
+		}
+		return true, ProviderResolutionDetail{Reason: TargetingMatchReason, Variant: "on"}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+
+	tests := map[string]struct {
+		flag        string
+		wantVariant string
+		wantErr     bool
+	}{
+		"variant":    {flag: "flag", wantVariant: "on"},
+		"no variant": {flag: "variantless", wantErr: true},
+		"not found":  {flag: "missing", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			variant, err := client.Variant(context.Background(), test.flag, EvaluationContext{})
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, got %v", test.wantErr, err)
+			}
+			if variant != test.wantVariant {
+				t.Errorf("expected variant %q, got %q", test.wantVariant, variant)
+			}
+		})
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		switch flag {
+		case "missing":
+			return defaultValue, ProviderResolutionDetail{ResolutionError: NewFlagNotFoundResolutionError("missing"), Reason: ErrorReason}
+		case "malformed":
+			return "soon", ProviderResolutionDetail{Reason: StaticReason}
+		}
+		return "1m30s", ProviderResolutionDetail{Reason: StaticReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+
+	tests := map[string]struct {
+		flag          string
+		wantValue     time.Duration
+		wantErrorCode ErrorCode
+	}{
+		"duration":  {flag: "timeout", wantValue: 90 * time.Second},
+		"malformed": {flag: "malformed", wantValue: time.Second, wantErrorCode: ParseErrorCode},
+		"not found": {flag: "missing", wantValue: time.Second, wantErrorCode: FlagNotFoundCode},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			details, err := client.DurationValueDetails(context.Background(), test.flag, time.Second, EvaluationContext{})
+			if (err != nil) != (test.wantErrorCode != "") {
+				t.Errorf("unexpected error %v", err)
+			}
+			if details.Value != test.wantValue {
+				t.Errorf("expected value %s, got %s", test.wantValue, details.Value)
+			}
+			if details.ErrorCode != test.wantErrorCode {
+				t.Errorf("expected error code %q, got %q", test.wantErrorCode, details.ErrorCode)
+			}
+			if test.wantErrorCode != "" && details.Reason != ErrorReason {
+				t.Errorf("expected reason %s, got %s", ErrorReason, details.Reason)
+			}
+
+			value, _ := client.DurationValue(context.Background(), test.flag, time.Second, EvaluationContext{})
+			if value != test.wantValue {
+				t.Errorf("expected value %s, got %s", test.wantValue, value)
+			}
+		})
+	}
+}
+
+type enumeratingProvider struct {
+	FuncProvider
+}
+
+func (p enumeratingProvider) FlagSchema() map[string]Type {
+	return map[string]Type{
+		"checkout.enabled":  Boolean,
+		"checkout.retries":  Int,
+		"checkout.currency": String,
+		"checkout.broken":   Float,
+		"search.enabled":    Boolean,
+	}
+}
+
+func TestEvaluateByPrefix(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := enumeratingProvider{NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		values := map[string]interface{}{
+			"checkout.enabled":  true,
+			"checkout.retries":  int64(3),
+			"checkout.currency": "EUR",
+			"search.enabled":    true,
+		}
+		value, ok := values[flag]
+		if !ok {
+			return defaultValue, ProviderResolutionDetail{ResolutionError: NewGeneralResolutionError("boom"), Reason: ErrorReason}
+		}
+		return value, ProviderResolutionDetail{Reason: StaticReason}
+	})}
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+
+	results, err := client.EvaluateByPrefix(context.Background(), "checkout.", EvaluationContext{})
+	if err == nil {
+		t.Error("expected the error of the failing flag to be returned")
+	}
+
+	want := map[string]interface{}{
+		"checkout.enabled":  true,
+		"checkout.retries":  int64(3),
+		"checkout.currency": "EUR",
+		"checkout.broken":   float64(0),
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %v", len(want), results)
+	}
+	for flag, value := range want {
+		if results[flag].Value != value {
+			t.Errorf("expected %s to be %v, got %v", flag, value, results[flag].Value)
+		}
+	}
+	if results["checkout.broken"].ErrorCode != GeneralCode {
+		t.Errorf("expected error code %s, got %s", GeneralCode, results["checkout.broken"].ErrorCode)
+	}
+
+	t.Run("provider without enumeration", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+		if err := api.SetProviderAndWait(NoopProvider{}); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+		client := newClient(t.Name(), api, executor)
+
+		if _, err := client.EvaluateByPrefix(context.Background(), "checkout.", EvaluationContext{}); !errors.Is(err, ErrOperationNotSupported) {
+			t.Errorf("expected an unsupported operation error, got %v", err)
+		}
+	})
+}
+
+func TestWithDeprecationHandler(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		metadata := map[string]FlagMetadata{
+			"retired":   {DeprecatedFlagMetadataKey: true},
+			"replaced":  {DeprecatedFlagMetadataKey: "use new-checkout instead"},
+			"undecided": {DeprecatedFlagMetadataKey: false},
+		}
+		return true, ProviderResolutionDetail{Reason: StaticReason, FlagMetadata: metadata[flag]}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	tests := map[string]struct {
+		flag           string
+		wantDeprecated bool
+		wantMessage    string
+	}{
+		"deprecated":              {flag: "retired", wantDeprecated: true},
+		"deprecated with message": {flag: "replaced", wantDeprecated: true, wantMessage: "use new-checkout instead"},
+		"not deprecated":          {flag: "undecided"},
+		"no metadata":             {flag: "flag"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			var gotFlag, gotMessage string
+			client := newClient(t.Name(), api, executor, WithDeprecationHandler(func(flagKey string, message string) {
+				calls++
+				gotFlag, gotMessage = flagKey, message
+			}))
+
+			client.Boolean(context.Background(), test.flag, false, EvaluationContext{})
+
+			if !test.wantDeprecated {
+				if calls != 0 {
+					t.Errorf("expected no deprecation, got %d calls", calls)
+				}
+				return
+			}
+			if calls != 1 || gotFlag != test.flag || gotMessage != test.wantMessage {
+				t.Errorf("expected one deprecation of %s with message %q, got %d calls for %s with %q",
+					test.flag, test.wantMessage, calls, gotFlag, gotMessage)
+			}
+		})
+	}
+}
+
+func TestEvaluationCallbackPanics(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		return defaultValue, ProviderResolutionDetail{
+			ResolutionError: NewFlagNotFoundResolutionError(flag),
+			Reason:          ErrorReason,
+			FlagMetadata:    FlagMetadata{DeprecatedFlagMetadataKey: true},
+		}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	var subscriberCalls int
+	api.SubscribeEvaluations(func(EvaluationEvent) {
+		panic("subscriber failure")
+	})
+	api.SubscribeEvaluations(func(EvaluationEvent) {
+		subscriberCalls++
+	})
+	client := newClient(t.Name(), api, executor,
+		WithDeprecationHandler(func(string, string) {
+			panic("deprecation handler failure")
+		}),
+		WithEvaluationErrorCallback(func(string, error, ErrorCode) {
+			panic("error callback failure")
+		}),
+		WithAuditSink(func(AuditRecord) {
+			panic("audit sink failure")
+		}),
+	)
+
+	value, err := client.BooleanValue(context.Background(), "flag", true, EvaluationContext{})
+	if !value || ErrorCodeOf(err) != FlagNotFoundCode {
+		t.Errorf("expected the evaluation result despite panicking callbacks, got %t and %v", value, err)
+	}
+	if subscriberCalls != 1 {
+		t.Errorf("expected the other subscriber to be called, got %d calls", subscriberCalls)
+	}
+}
+
+type sleepingHook struct {
+	UnimplementedHook
+	sleep     time.Duration
+	deadlines chan time.Time
+}
+
+func (h sleepingHook) Before(ctx context.Context, hookContext HookContext, hookHints HookHints) (*EvaluationContext, error) {
+	deadline, _ := ctx.Deadline()
+	h.deadlines <- deadline
+	time.Sleep(h.sleep)
+	return nil, nil
+}
+
+func TestTimeoutBudgetSharedWithHooks(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	providerCalls := 0
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		providerCalls++
+		return true, ProviderResolutionDetail{Reason: StaticReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	t.Run("hooks share the deadline", func(t *testing.T) {
+		deadlines := make(chan time.Time, 2)
+		hook := sleepingHook{deadlines: deadlines}
+		client := newClient(t.Name(), api, executor)
+		client.AddHooks(hook, hook)
+
+		if _, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}, WithTimeout(time.Minute)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		first, second := <-deadlines, <-deadlines
+		if first.IsZero() || !first.Equal(second) {
+			t.Errorf("expected both hooks to get the same deadline, got %s and %s", first, second)
+		}
+	})
+
+	t.Run("exhausted budget skips the provider", func(t *testing.T) {
+		providerCalls = 0
+		client := newClient(t.Name(), api, executor)
+		client.AddHooks(sleepingHook{sleep: 20 * time.Millisecond, deadlines: make(chan time.Time, 1)})
+
+		details, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{}, WithTimeout(time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a deadline exceeded error, got %v", err)
+		}
+		if details.ErrorCode != GeneralCode {
+			t.Errorf("expected error code %s, got %s", GeneralCode, details.ErrorCode)
+		}
+		if details.Value {
+			t.Error("expected the default value to be returned")
+		}
+		if providerCalls != 0 {
+			t.Errorf("expected the provider not to be called, got %d calls", providerCalls)
+		}
+	})
+
+	t.Run("canceled context without deadline still calls the provider", func(t *testing.T) {
+		providerCalls = 0
+		client := newClient(t.Name(), api, executor)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		value, err := client.BooleanValue(ctx, "flag", false, EvaluationContext{})
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if !value || providerCalls != 1 {
+			t.Errorf("expected the provider to be called, got %t after %d calls", value, providerCalls)
+		}
+	})
+}
+
+func TestWithDefaultTimeout(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	deadlines := make(chan time.Time, 1)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		deadline, _ := ctx.Deadline()
+		deadlines <- deadline
+		return defaultValue, ProviderResolutionDetail{Reason: DefaultReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	tests := map[string]struct {
+		defaultTimeout time.Duration
+		parentTimeout  time.Duration
+		options        []Option
+		wantTimeout    time.Duration
+	}{
+		"no timeout":             {},
+		"default timeout":        {defaultTimeout: time.Minute, wantTimeout: time.Minute},
+		"per-call timeout":       {defaultTimeout: time.Minute, options: []Option{WithTimeout(time.Hour)}, wantTimeout: time.Hour},
+		"sooner caller deadline": {defaultTimeout: time.Hour, parentTimeout: time.Minute, wantTimeout: time.Minute},
+		"per-call timeout alone": {options: []Option{WithTimeout(time.Minute)}, wantTimeout: time.Minute},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t.Name(), api, executor, WithDefaultTimeout(test.defaultTimeout))
+			ctx := context.Background()
+			if test.parentTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.parentTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			client.Boolean(ctx, "flag", false, EvaluationContext{}, test.options...)
+			deadline := <-deadlines
+
+			if test.wantTimeout == 0 {
+				if !deadline.IsZero() {
+					t.Errorf("expected no deadline, got %s", deadline)
+				}
+				return
+			}
+			if timeout := deadline.Sub(start); timeout < test.wantTimeout-time.Second || timeout > test.wantTimeout+time.Second {
+				t.Errorf("expected a deadline in %s, got %s", test.wantTimeout, timeout)
+			}
+		})
+	}
+}
+
+// freshnessProvider is a provider reporting when its data was last updated
+type freshnessProvider struct {
+	NoopProvider
+	lastUpdated time.Time
+}
+
+func (p freshnessProvider) LastUpdated() time.Time {
+	return p.lastUpdated
+}
+
+func TestValueAgeFlagMetadata(t *testing.T) {
+	tests := map[string]struct {
+		provider FeatureProvider
+		wantAge  bool
+	}{
+		"reported":           {provider: freshnessProvider{lastUpdated: time.Now().Add(-time.Minute)}, wantAge: true},
+		"reported decorated": {provider: NewOverrideProvider(freshnessProvider{lastUpdated: time.Now().Add(-time.Minute)}), wantAge: true},
+		"unknown":            {provider: freshnessProvider{}},
+		"not reported":       {provider: NoopProvider{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			executor := newEventExecutor()
+			api := newEvaluationAPI(executor)
+			if err := api.SetProviderAndWait(test.provider); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+			client := newClient(t.Name(), api, executor)
+
+			details, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			age, err := details.FlagMetadata.GetInt(ValueAgeMsFlagMetadataKey)
+			if !test.wantAge {
+				if err == nil {
+					t.Errorf("expected no value age, got %d", age)
+				}
+				return
+			}
+			if err != nil || age < time.Minute.Milliseconds() || age > (time.Minute+time.Second).Milliseconds() {
+				t.Errorf("expected a value age of a minute, got %d (%v)", age, err)
+			}
+		})
+	}
+}
+
+func TestNewEvaluationOptions(t *testing.T) {
+	var count int
+	hints := NewHookHints(map[string]interface{}{"hint": "value"})
+	evalOptions := NewEvaluationOptions(WithHooks(countingHook{count: &count}), WithHookHints(hints))
+
+	if len(evalOptions.Hooks()) != 1 || !reflect.DeepEqual(evalOptions.HookHints(), hints) {
+		t.Errorf("expected the options to be applied, got %+v", evalOptions)
+	}
+
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	client.Boolean(context.Background(), "flag", false, EvaluationContext{}, WithOptions(evalOptions))
+	client.String(context.Background(), "flag", "", EvaluationContext{}, WithOptions(evalOptions))
+	if count != 2 {
+		t.Errorf("expected the hook of the options to run for each evaluation, got %d runs", count)
+	}
+
+	// later options apply on top of the pre-built ones
+	client.Boolean(context.Background(), "flag", false, EvaluationContext{}, WithOptions(evalOptions), WithHooks())
+	if count != 2 {
+		t.Errorf("expected the hooks to be replaced, got %d runs", count)
+	}
+}
+
+// defaultContextProvider is a provider supplying a default context, capturing the flattened context it resolves with
+type defaultContextProvider struct {
+	flatContextCapturingProvider
+}
+
+func (p defaultContextProvider) DefaultContext() EvaluationContext {
+	return NewEvaluationContext("provider", map[string]interface{}{
+		"region": "provider",
+		"env":    "provider",
+		"zone":   "provider",
+	})
+}
+
+func TestProviderDefaultContext(t *testing.T) {
+	var resolvedCtx FlattenedContext
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	api.SetEvaluationContext(NewTargetlessEvaluationContext(map[string]interface{}{"region": "api"}))
+	if err := api.SetProviderAndWait(defaultContextProvider{flatContextCapturingProvider{resolvedCtx: &resolvedCtx}}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+	client.SetEvaluationContext(NewTargetlessEvaluationContext(map[string]interface{}{"env": "client"}))
+
+	t.Run("merged at the lowest precedence", func(t *testing.T) {
+		client.Boolean(context.Background(), "flag", false, NewEvaluationContext("user", nil))
+
+		want := FlattenedContext{TargetingKey: "user", "region": "api", "env": "client", "zone": "provider"}
+		if !reflect.DeepEqual(resolvedCtx, want) {
+			t.Errorf("expected context %v, got %v", want, resolvedCtx)
+		}
+	})
+
+	t.Run("not merged into exclusive contexts", func(t *testing.T) {
+		client.Boolean(context.Background(), "flag", false, NewEvaluationContext("user", nil), WithExclusiveContext())
+
+		want := FlattenedContext{TargetingKey: "user"}
+		if !reflect.DeepEqual(resolvedCtx, want) {
+			t.Errorf("expected context %v, got %v", want, resolvedCtx)
+		}
+	})
+}
+
+func TestWithRequireProvider(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		return int64(7), ProviderResolutionDetail{Reason: StaticReason}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	tests := map[string]struct {
+		state    State
+		wantCode ErrorCode
+	}{
+		"ready":     {state: ReadyState},
+		"stale":     {state: StaleState, wantCode: ProviderNotReadyCode},
+		"error":     {state: ErrorState, wantCode: ProviderNotReadyCode},
+		"not ready": {state: NotReadyState, wantCode: ProviderNotReadyCode},
+		"fatal":     {state: FatalState, wantCode: ProviderFatalCode},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t.Name(), api, executor)
+			executor.storeState(t.Name(), test.state)
+
+			details, err := client.IntValueDetails(context.Background(), "flag", 42, EvaluationContext{}, WithRequireProvider())
+			if test.wantCode == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrProviderRequired) {
+				t.Errorf("expected a provider required error, got %v", err)
+			}
+			if details.ErrorCode != test.wantCode {
+				t.Errorf("expected error code %s, got %s", test.wantCode, details.ErrorCode)
+			}
+			if details.Value != 0 {
+				t.Errorf("expected the zero value rather than the default, got %d", details.Value)
+			}
+			if value, _ := client.ObjectValue(context.Background(), "flag", "default", EvaluationContext{}, WithRequireProvider()); value != nil {
+				t.Errorf("expected no object value, got %v", value)
+			}
+		})
+	}
+
+	t.Run("without the option", func(t *testing.T) {
+		client := newClient(t.Name(), api, executor)
+		executor.storeState(t.Name(), StaleState)
+
+		if _, err := client.IntValue(context.Background(), "flag", 42, EvaluationContext{}); err != nil {
+			t.Errorf("expected a stale provider to be evaluated, got %v", err)
+		}
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+		client := newClient(t.Name(), api, executor)
+
+		details, err := client.IntValueDetails(context.Background(), "flag", 42, EvaluationContext{}, WithRequireProvider())
+		if !errors.Is(err, ErrProviderRequired) || !errors.Is(err, ProviderNotReadyError) {
+			t.Errorf("expected a provider required error, got %v", err)
+		}
+		if details.ErrorCode != ProviderNotReadyCode {
+			t.Errorf("expected error code %s, got %s", ProviderNotReadyCode, details.ErrorCode)
+		}
+		if details.Value != 0 {
+			t.Errorf("expected the zero value rather than the default, got %d", details.Value)
+		}
+	})
+}
+
+func TestDisabledFlags(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	// providers may report an error along with the disabled reason
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		return nil, ProviderResolutionDetail{
+			ResolutionError: NewGeneralResolutionError("flag is disabled"),
+			Reason:          DisabledReason,
+		}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+	ctx := context.Background()
+
+	check := func(t *testing.T, value, defaultValue interface{}, details EvaluationDetails, err error) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if value != defaultValue {
+			t.Errorf("expected the default value %v, got %v", defaultValue, value)
+		}
+		if !details.IsDisabled() || details.ErrorCode != "" {
+			t.Errorf("expected a disabled flag without error code, got %+v", details)
+		}
+	}
+
+	t.Run("boolean", func(t *testing.T) {
+		details, err := client.BooleanValueDetails(ctx, "flag", true, EvaluationContext{})
+		check(t, details.Value, true, details.EvaluationDetails, err)
+	})
+	t.Run("string", func(t *testing.T) {
+		details, err := client.StringValueDetails(ctx, "flag", "default", EvaluationContext{})
+		check(t, details.Value, "default", details.EvaluationDetails, err)
+	})
+	t.Run("float", func(t *testing.T) {
+		details, err := client.FloatValueDetails(ctx, "flag", 0.5, EvaluationContext{})
+		check(t, details.Value, 0.5, details.EvaluationDetails, err)
+	})
+	t.Run("int", func(t *testing.T) {
+		details, err := client.IntValueDetails(ctx, "flag", 1, EvaluationContext{})
+		check(t, details.Value, int64(1), details.EvaluationDetails, err)
+	})
+	t.Run("object", func(t *testing.T) {
+		details, err := client.ObjectValueDetails(ctx, "flag", "default", EvaluationContext{})
+		check(t, details.Value, "default", details.EvaluationDetails, err)
+	})
+}
@@ -0,0 +1,101 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentSingletonUsage exercises the singleton API from many goroutines at once: provider registration,
+// evaluation, event dispatch and handler registration, global context and hooks. It is meant to be run with the race
+// detector.
+func TestConcurrentSingletonUsage(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	const goroutines = 10
+	const iterations = 50
+
+	eventing := &ProviderEventing{c: make(chan Event, goroutines)}
+	eventingProvider := struct {
+		FeatureProvider
+		EventHandler
+	}{
+		NoopProvider{},
+		eventing,
+	}
+
+	var wg sync.WaitGroup
+	run := func(f func(i int)) {
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					f(i)
+				}
+			}()
+		}
+	}
+
+	run(func(i int) {
+		if i%2 == 0 {
+			_ = SetProvider(NoopProvider{})
+		} else {
+			_ = SetProvider(eventingProvider)
+		}
+	})
+	run(func(i int) {
+		_ = SetNamedProvider(fmt.Sprintf("domain-%d", i%3), eventingProvider)
+	})
+	run(func(i int) {
+		client := NewClient(fmt.Sprintf("domain-%d", i%4))
+		client.Boolean(context.Background(), "flag", false, NewEvaluationContext("user", nil))
+		client.Track(context.Background(), "event", EvaluationContext{}, TrackingEventDetails{})
+		_ = client.State()
+	})
+	run(func(i int) {
+		select {
+		case eventing.c <- Event{EventType: ProviderConfigChange}:
+		default:
+		}
+	})
+	run(func(i int) {
+		callback := func(EventDetails) {}
+		AddHandler(ProviderConfigChange, &callback)
+		client := NewClient(fmt.Sprintf("domain-%d", i%3))
+		client.AddHandler(ProviderReady, &callback)
+		client.RemoveHandler(ProviderReady, &callback)
+		RemoveHandler(ProviderConfigChange, &callback)
+	})
+	run(func(i int) {
+		SetEvaluationContext(NewTargetlessEvaluationContext(map[string]interface{}{"iteration": i}))
+		if i%10 == 0 {
+			AddHooks(UnimplementedHook{})
+		}
+	})
+	wg.Wait()
+
+	// the API is still consistent once the dust settles
+	if err := SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	if err := SetNamedProviderAndWait("domain-0", NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	for _, domain := range []string{"", "domain-0"} {
+		client := NewClient(domain)
+		eventually(t, func() bool {
+			return client.State() == ReadyState
+		}, time.Second, 10*time.Millisecond, fmt.Sprintf("provider of domain %q not ready", domain))
+
+		if value, err := client.StringValue(context.Background(), "flag", "default", EvaluationContext{}); err != nil || value != "default" {
+			t.Errorf("expected the default provider result for domain %q, got %q (%v)", domain, value, err)
+		}
+	}
+	if got := NamedProviderMetadata("domain-0").Name; got != (NoopProvider{}).Metadata().Name {
+		t.Errorf("expected the last provider to be bound, got %s", got)
+	}
+}
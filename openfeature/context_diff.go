@@ -0,0 +1,99 @@
+package openfeature
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ContextDiff is the difference between two evaluation contexts, see DiffEvaluationContexts.
+// Attributes of nested maps are compared one by one and identified by their dot-joined path (e.g. "user.plan").
+type ContextDiff struct {
+	// TargetingKey holds the change of the targeting key, if it differs
+	TargetingKey *AttributeChange
+	// Added holds the attributes only set in the second context
+	Added []AttributeChange
+	// Removed holds the attributes only set in the first context
+	Removed []AttributeChange
+	// Changed holds the attributes set in both contexts with different values
+	Changed []AttributeChange
+}
+
+// AttributeChange describes an attribute differing between two evaluation contexts. Before is nil for added
+// attributes and After is nil for removed attributes.
+type AttributeChange struct {
+	Key    string
+	Before interface{}
+	After  interface{}
+}
+
+// DiffEvaluationContexts returns the difference between the evaluation contexts a and b, e.g. to find out why two
+// similar requests got different flag values. Attributes are listed in key order.
+func DiffEvaluationContexts(a, b EvaluationContext) ContextDiff {
+	var diff ContextDiff
+	if a.targetingKey != b.targetingKey {
+		diff.TargetingKey = &AttributeChange{Key: TargetingKey, Before: a.targetingKey, After: b.targetingKey}
+	}
+	diffAttributes(&diff, "", a.attributes, b.attributes)
+	return diff
+}
+
+// Empty returns whether the contexts compared are identical
+func (d ContextDiff) Empty() bool {
+	return d.TargetingKey == nil && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns the diff with one line per change, prefixed with "+" for added, "-" for removed and "~" for changed
+// attributes
+func (d ContextDiff) String() string {
+	var lines []string
+	if d.TargetingKey != nil {
+		lines = append(lines, fmt.Sprintf("~ %s: %q -> %q", d.TargetingKey.Key, d.TargetingKey.Before, d.TargetingKey.After))
+	}
+	for _, change := range d.Added {
+		lines = append(lines, fmt.Sprintf("+ %s: %v", change.Key, change.After))
+	}
+	for _, change := range d.Removed {
+		lines = append(lines, fmt.Sprintf("- %s: %v", change.Key, change.Before))
+	}
+	for _, change := range d.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s: %v -> %v", change.Key, change.Before, change.After))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffAttributes adds the differences between the attributes a and b to diff, recursing into nested maps
+func diffAttributes(diff *ContextDiff, prefix string, a, b map[string]interface{}) {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		before, inA := a[key]
+		after, inB := b[key]
+		path := prefix + key
+
+		switch {
+		case !inA:
+			diff.Added = append(diff.Added, AttributeChange{Key: path, After: after})
+		case !inB:
+			diff.Removed = append(diff.Removed, AttributeChange{Key: path, Before: before})
+		default:
+			nestedBefore, beforeIsMap := before.(map[string]interface{})
+			nestedAfter, afterIsMap := after.(map[string]interface{})
+			if beforeIsMap && afterIsMap {
+				diffAttributes(diff, path+".", nestedBefore, nestedAfter)
+			} else if !reflect.DeepEqual(before, after) {
+				diff.Changed = append(diff.Changed, AttributeChange{Key: path, Before: before, After: after})
+			}
+		}
+	}
+}
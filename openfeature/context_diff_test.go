@@ -0,0 +1,47 @@
+package openfeature
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffEvaluationContexts(t *testing.T) {
+	a := NewEvaluationContext("user-1", map[string]interface{}{
+		"country": "fr",
+		"beta":    true,
+		"user":    map[string]interface{}{"plan": "free", "age": 30},
+	})
+	b := NewEvaluationContext("user-2", map[string]interface{}{
+		"country": "de",
+		"device":  "mobile",
+		"user":    map[string]interface{}{"plan": "pro", "age": 30},
+	})
+
+	diff := DiffEvaluationContexts(a, b)
+
+	want := ContextDiff{
+		TargetingKey: &AttributeChange{Key: TargetingKey, Before: "user-1", After: "user-2"},
+		Added:        []AttributeChange{{Key: "device", After: "mobile"}},
+		Removed:      []AttributeChange{{Key: "beta", Before: true}},
+		Changed: []AttributeChange{
+			{Key: "country", Before: "fr", After: "de"},
+			{Key: "user.plan", Before: "free", After: "pro"},
+		},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("expected diff %+v, got %+v", want, diff)
+	}
+
+	wantString := `~ targetingKey: "user-1" -> "user-2"
++ device: mobile
+- beta: true
+~ country: fr -> de
+~ user.plan: free -> pro`
+	if diff.String() != wantString {
+		t.Errorf("expected diff to print as\n%s\ngot\n%s", wantString, diff.String())
+	}
+
+	if !DiffEvaluationContexts(a, a).Empty() {
+		t.Error("expected identical contexts not to differ")
+	}
+}
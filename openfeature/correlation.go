@@ -0,0 +1,41 @@
+package openfeature
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/open-feature/go-sdk/openfeature/internal"
+)
+
+// WithCorrelationID returns a copy of ctx carrying the given correlation id.
+//
+// The correlation id travels with the context.Context of flag evaluations and tracking events to providers and hooks,
+// so that all calls of an evaluation can be correlated. Providers making remote calls can retrieve it with
+// CorrelationID and forward it, e.g. as a request header. Clients created with WithGeneratedCorrelationIDs generate a
+// correlation id when the context does not carry one.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, internal.CorrelationID, id)
+}
+
+// CorrelationID returns the correlation id carried by ctx, if any
+func CorrelationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(internal.CorrelationID).(string)
+	return id, ok && id != ""
+}
+
+// ensureCorrelationID returns ctx if it carries a correlation id, otherwise a copy of ctx carrying a generated one
+func ensureCorrelationID(ctx context.Context) context.Context {
+	if _, ok := CorrelationID(ctx); ok {
+		return ctx
+	}
+	return WithCorrelationID(ctx, newCorrelationID())
+}
+
+// newCorrelationID generates a random correlation id
+func newCorrelationID() string {
+	var id [16]byte
+	// crypto/rand.Read never returns an error on supported platforms
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
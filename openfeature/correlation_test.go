@@ -0,0 +1,68 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+// correlationProvider records the correlation id of the evaluation context.Context
+type correlationProvider struct {
+	NoopProvider
+	id *string
+}
+
+func (p correlationProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	*p.id, _ = CorrelationID(ctx)
+	return p.NoopProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// correlationHook records the correlation id of the before hook context.Context
+type correlationHook struct {
+	UnimplementedHook
+	id *string
+}
+
+func (h correlationHook) Before(ctx context.Context, _ HookContext, _ HookHints) (*EvaluationContext, error) {
+	*h.id, _ = CorrelationID(ctx)
+	return nil, nil
+}
+
+func TestCorrelationID(t *testing.T) {
+	evaluate := func(t *testing.T, ctx context.Context, options ...ClientOption) (string, string) {
+		t.Helper()
+		var providerID, hookID string
+
+		executor := newEventExecutor()
+		client := newClient(t.Name(), newEvaluationAPI(executor), executor, options...)
+		if err := client.api.SetProviderAndWait(correlationProvider{id: &providerID}); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		_, err := client.BooleanValue(ctx, "flag", false, EvaluationContext{}, WithHooks(correlationHook{id: &hookID}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return providerID, hookID
+	}
+
+	t.Run("caller correlation id reaches provider and hooks", func(t *testing.T) {
+		providerID, hookID := evaluate(t, WithCorrelationID(context.Background(), "correlation-id"), WithGeneratedCorrelationIDs())
+		if providerID != "correlation-id" || hookID != "correlation-id" {
+			t.Errorf("expected the caller correlation id, got %q for the provider and %q for the hook", providerID, hookID)
+		}
+	})
+
+	t.Run("correlation id is generated when missing", func(t *testing.T) {
+		providerID, hookID := evaluate(t, context.Background(), WithGeneratedCorrelationIDs())
+		if providerID == "" || providerID != hookID {
+			t.Errorf("expected a generated correlation id, got %q for the provider and %q for the hook", providerID, hookID)
+		}
+	})
+
+	t.Run("correlation id is not generated by default", func(t *testing.T) {
+		providerID, _ := evaluate(t, context.Background())
+		if providerID != "" {
+			t.Errorf("expected no correlation id, got %q", providerID)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetDefaultRegistry sets the registry of default values of the client, mapping flag keys to their default value.
+// The registry centralizes the default of each flag for the *FromRegistry evaluation functions, so that the same flag
+// is not evaluated with inconsistent defaults across call sites. The type of a registered default determines the
+// type of the flag: bool, string, float64, int64 or any other type for object flags.
+func (c *Client) SetDefaultRegistry(defaults map[string]interface{}) {
+	registry := make(map[string]interface{}, len(defaults))
+	for flag, defaultValue := range defaults {
+		registry[flag] = defaultValue
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.defaultRegistry = registry
+}
+
+// BooleanValueFromRegistry performs a flag evaluation that returns a boolean, with the default registered for the
+// flag in the client's default registry. An error is returned, without evaluating the flag, if the flag is not
+// registered or its registered default is not a boolean.
+func (c *Client) BooleanValueFromRegistry(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (bool, error) {
+	defaultValue, err := registeredDefault[bool](c, flag)
+	if err != nil {
+		return defaultValue, err
+	}
+	return c.BooleanValue(ctx, flag, defaultValue, evalCtx, options...)
+}
+
+// StringValueFromRegistry performs a flag evaluation that returns a string, with the default registered for the flag
+// in the client's default registry. An error is returned, without evaluating the flag, if the flag is not registered
+// or its registered default is not a string.
+func (c *Client) StringValueFromRegistry(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (string, error) {
+	defaultValue, err := registeredDefault[string](c, flag)
+	if err != nil {
+		return defaultValue, err
+	}
+	return c.StringValue(ctx, flag, defaultValue, evalCtx, options...)
+}
+
+// FloatValueFromRegistry performs a flag evaluation that returns a float64, with the default registered for the flag
+// in the client's default registry. An error is returned, without evaluating the flag, if the flag is not registered
+// or its registered default is not a float64.
+func (c *Client) FloatValueFromRegistry(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (float64, error) {
+	defaultValue, err := registeredDefault[float64](c, flag)
+	if err != nil {
+		return defaultValue, err
+	}
+	return c.FloatValue(ctx, flag, defaultValue, evalCtx, options...)
+}
+
+// IntValueFromRegistry performs a flag evaluation that returns an int64, with the default registered for the flag in
+// the client's default registry. An error is returned, without evaluating the flag, if the flag is not registered or
+// its registered default is not an int64.
+func (c *Client) IntValueFromRegistry(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (int64, error) {
+	defaultValue, err := registeredDefault[int64](c, flag)
+	if err != nil {
+		return defaultValue, err
+	}
+	return c.IntValue(ctx, flag, defaultValue, evalCtx, options...)
+}
+
+// ObjectValueFromRegistry performs a flag evaluation that returns an object, with the default registered for the
+// flag in the client's default registry. An error is returned, without evaluating the flag, if the flag is not
+// registered.
+func (c *Client) ObjectValueFromRegistry(ctx context.Context, flag string, evalCtx EvaluationContext, options ...Option) (interface{}, error) {
+	defaultValue, err := registeredDefault[interface{}](c, flag)
+	if err != nil {
+		return defaultValue, err
+	}
+	return c.ObjectValue(ctx, flag, defaultValue, evalCtx, options...)
+}
+
+// registeredDefault returns the default registered for the flag, if it is of type T
+func registeredDefault[T any](c *Client, flag string) (T, error) {
+	c.mx.RLock()
+	registered, ok := c.defaultRegistry[flag]
+	c.mx.RUnlock()
+
+	var defaultValue T
+	if !ok {
+		return defaultValue, fmt.Errorf("flag %q is not registered in the default registry", flag)
+	}
+
+	defaultValue, ok = registered.(T)
+	// a nil default is valid for object flags, for which T is an interface
+	if !ok && (registered != nil || any(defaultValue) != nil) {
+		return defaultValue, fmt.Errorf("default of flag %q is registered as %T, not %T", flag, registered, defaultValue)
+	}
+
+	return defaultValue, nil
+}
@@ -0,0 +1,74 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestClientDefaultRegistry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProvider := NewMockFeatureProvider(ctrl)
+	mockProvider.EXPECT().Metadata().AnyTimes()
+	mockProvider.EXPECT().Hooks().AnyTimes()
+
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(mockProvider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	client.SetDefaultRegistry(map[string]interface{}{
+		"boolFlag":   true,
+		"stringFlag": "default",
+		"floatFlag":  1.5,
+		"intFlag":    int64(3),
+		"objectFlag": nil,
+	})
+
+	t.Run("evaluates with the registered default", func(t *testing.T) {
+		mockProvider.EXPECT().BooleanEvaluation(gomock.Any(), "boolFlag", true, gomock.Any()).
+			Return(BoolResolutionDetail{Value: false})
+		mockProvider.EXPECT().StringEvaluation(gomock.Any(), "stringFlag", "default", gomock.Any()).
+			Return(StringResolutionDetail{Value: "value"})
+		mockProvider.EXPECT().FloatEvaluation(gomock.Any(), "floatFlag", 1.5, gomock.Any()).
+			Return(FloatResolutionDetail{Value: 2.5})
+		mockProvider.EXPECT().IntEvaluation(gomock.Any(), "intFlag", int64(3), gomock.Any()).
+			Return(IntResolutionDetail{Value: 4})
+		mockProvider.EXPECT().ObjectEvaluation(gomock.Any(), "objectFlag", nil, gomock.Any()).
+			Return(InterfaceResolutionDetail{Value: "object"})
+
+		ctx := context.Background()
+		if value, err := client.BooleanValueFromRegistry(ctx, "boolFlag", EvaluationContext{}); err != nil || value {
+			t.Errorf("unexpected result %v, %v", value, err)
+		}
+		if value, err := client.StringValueFromRegistry(ctx, "stringFlag", EvaluationContext{}); err != nil || value != "value" {
+			t.Errorf("unexpected result %v, %v", value, err)
+		}
+		if value, err := client.FloatValueFromRegistry(ctx, "floatFlag", EvaluationContext{}); err != nil || value != 2.5 {
+			t.Errorf("unexpected result %v, %v", value, err)
+		}
+		if value, err := client.IntValueFromRegistry(ctx, "intFlag", EvaluationContext{}); err != nil || value != 4 {
+			t.Errorf("unexpected result %v, %v", value, err)
+		}
+		if value, err := client.ObjectValueFromRegistry(ctx, "objectFlag", EvaluationContext{}); err != nil || value != "object" {
+			t.Errorf("unexpected result %v, %v", value, err)
+		}
+	})
+
+	t.Run("errors without evaluating for unregistered flags", func(t *testing.T) {
+		if _, err := client.BooleanValueFromRegistry(context.Background(), "unknown", EvaluationContext{}); err == nil {
+			t.Error("expected an error for an unregistered flag")
+		}
+	})
+
+	t.Run("errors without evaluating for a default of another type", func(t *testing.T) {
+		if _, err := client.StringValueFromRegistry(context.Background(), "boolFlag", EvaluationContext{}); err == nil {
+			t.Error("expected an error for a default of another type")
+		}
+		if _, err := client.BooleanValueFromRegistry(context.Background(), "objectFlag", EvaluationContext{}); err == nil {
+			t.Error("expected an error for a nil default of a boolean flag")
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package openfeature
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/open-feature/go-sdk/openfeature/internal"
+)
+
+// WithDefaultSerializer sets a function converting the default value of object evaluations (e.g. a struct) into a
+// map, for providers needing a map form of the default value, e.g. to merge a partial configuration into it. The map
+// travels with the context.Context given to the provider, which retrieves it with SerializedDefault; the original
+// default value is passed to ObjectEvaluation unchanged. Nil default values are not serialized. If the serialization
+// fails, the failure is logged and the evaluation goes on without the map form.
+func WithDefaultSerializer(serializer func(interface{}) (map[string]interface{}, error)) ClientOption {
+	return func(c *Client) {
+		c.defaultSerializer = serializer
+	}
+}
+
+// SerializedDefault returns the map form of the default value of the object evaluation carried by ctx, if any.
+// Meant to be used by providers, see WithDefaultSerializer.
+func SerializedDefault(ctx context.Context) (map[string]interface{}, bool) {
+	serialized, ok := ctx.Value(internal.SerializedDefault).(map[string]interface{})
+	return serialized, ok
+}
+
+// withSerializedDefault returns a copy of ctx carrying the map form of the default value, if the client has a default
+// serializer
+func (c *Client) withSerializedDefault(ctx context.Context, flag string, defaultValue interface{}) context.Context {
+	if c.defaultSerializer == nil || defaultValue == nil {
+		return ctx
+	}
+
+	serialized, err := c.defaultSerializer(defaultValue)
+	if err != nil {
+		slog.Warn("failed to serialize the default value of an object flag", "flag", flag, "error", err)
+		return ctx
+	}
+	return context.WithValue(ctx, internal.SerializedDefault, serialized)
+}
@@ -0,0 +1,66 @@
+package openfeature
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// serializedDefaultCapturingProvider is a provider capturing the serialized default of object evaluations
+type serializedDefaultCapturingProvider struct {
+	NoopProvider
+	serialized *map[string]interface{}
+}
+
+func (p serializedDefaultCapturingProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	*p.serialized, _ = SerializedDefault(ctx)
+	return p.NoopProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func TestWithDefaultSerializer(t *testing.T) {
+	type settings struct {
+		Theme string
+	}
+	serializer := func(v interface{}) (map[string]interface{}, error) {
+		s, ok := v.(settings)
+		if !ok {
+			return nil, errors.New("unsupported default")
+		}
+		return map[string]interface{}{"theme": s.Theme}, nil
+	}
+
+	tests := map[string]struct {
+		options      []ClientOption
+		defaultValue interface{}
+		want         map[string]interface{}
+	}{
+		"serialized":           {options: []ClientOption{WithDefaultSerializer(serializer)}, defaultValue: settings{Theme: "dark"}, want: map[string]interface{}{"theme": "dark"}},
+		"serialization failed": {options: []ClientOption{WithDefaultSerializer(serializer)}, defaultValue: "not settings"},
+		"nil default":          {options: []ClientOption{WithDefaultSerializer(serializer)}},
+		"no serializer":        {defaultValue: settings{Theme: "dark"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var serialized map[string]interface{}
+			executor := newEventExecutor()
+			api := newEvaluationAPI(executor)
+			if err := api.SetProviderAndWait(serializedDefaultCapturingProvider{serialized: &serialized}); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+			client := newClient(t.Name(), api, executor, test.options...)
+
+			value, err := client.ObjectValue(context.Background(), "flag", test.defaultValue, EvaluationContext{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(value, test.defaultValue) {
+				t.Errorf("expected the original default value %v, got %v", test.defaultValue, value)
+			}
+			if !reflect.DeepEqual(serialized, test.want) {
+				t.Errorf("expected serialized default %v, got %v", test.want, serialized)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package openfeature
+
+import (
+	"context"
+	"time"
+)
+
+// delegatingProvider is the base of provider decorators. It delegates every FeatureProvider function to the wrapped
+// provider and forwards the optional contracts (StateHandler, EventHandler, Tracker, Snapshotter, DefaultOverrider,
+// FlagSchema...) when the wrapped provider implements them, so that a decorated provider keeps its capabilities.
+// Note that a snapshot is taken from the wrapped provider, so that evaluations pinned to it bypass the decorator.
+//
+// As a consequence, decorators implement StateHandler, ContextInitializer and EventHandler whatever the wrapped
+// provider implements. They implement decorator, so that the SDK looks through them to tell whether the provider
+// needs an initialization or emits events.
+type delegatingProvider struct {
+	FeatureProvider
+}
+
+// decorator is implemented by provider decorators, telling whether the provider they decorate needs an
+// initialization and emits events
+type decorator interface {
+	requiresInitialization() bool
+	emitsEvents() bool
+}
+
+// requiresInitialization returns whether the wrapped provider has an initialization to run before being ready
+func (d delegatingProvider) requiresInitialization() bool {
+	return requiresInitialization(d.FeatureProvider)
+}
+
+// emitsEvents returns whether the wrapped provider emits events
+func (d delegatingProvider) emitsEvents() bool {
+	return emitsEvents(d.FeatureProvider)
+}
+
+// emitsEvents returns whether the provider emits events through its event channel, looking through decorators
+func emitsEvents(provider FeatureProvider) bool {
+	if d, ok := provider.(decorator); ok {
+		return d.emitsEvents()
+	}
+	_, ok := provider.(EventHandler)
+	return ok
+}
+
+// Init forwards initialization to the wrapped provider if it is a StateHandler
+func (d delegatingProvider) Init(evaluationContext EvaluationContext) error {
+	if handler, ok := d.FeatureProvider.(StateHandler); ok {
+		return handler.Init(evaluationContext)
+	}
+	return nil
+}
+
+// InitWithContext forwards initialization to the wrapped provider, with the context if it is a ContextInitializer
+func (d delegatingProvider) InitWithContext(ctx context.Context, evaluationContext EvaluationContext) error {
+	if initializer, ok := d.FeatureProvider.(ContextInitializer); ok {
+		return initializer.InitWithContext(ctx, evaluationContext)
+	}
+	return d.Init(evaluationContext)
+}
+
+// Shutdown forwards shutdown to the wrapped provider if it is a StateHandler
+func (d delegatingProvider) Shutdown() {
+	if handler, ok := d.FeatureProvider.(StateHandler); ok {
+		handler.Shutdown()
+	}
+}
+
+// EventChannel returns the event channel of the wrapped provider if it is an EventHandler. Otherwise, a nil channel
+// is returned, which never emits.
+func (d delegatingProvider) EventChannel() <-chan Event {
+	if handler, ok := d.FeatureProvider.(EventHandler); ok {
+		return handler.EventChannel()
+	}
+	return nil
+}
+
+// Track forwards the tracking event to the wrapped provider if it is a Tracker
+func (d delegatingProvider) Track(ctx context.Context, trackingEventName string, evaluationContext EvaluationContext, details TrackingEventDetails) {
+	if tracker, ok := d.FeatureProvider.(Tracker); ok {
+		tracker.Track(ctx, trackingEventName, evaluationContext, details)
+	}
+}
+
+// Reset forwards the reset to the wrapped provider if it is Resettable
+func (d delegatingProvider) Reset() {
+	if resettable, ok := d.FeatureProvider.(Resettable); ok {
+		resettable.Reset()
+	}
+}
+
+// LastUpdated forwards the update time of the wrapped provider if it is a FreshnessReporter, and returns the zero time
+// otherwise
+func (d delegatingProvider) LastUpdated() time.Time {
+	if reporter, ok := d.FeatureProvider.(FreshnessReporter); ok {
+		return reporter.LastUpdated()
+	}
+	return time.Time{}
+}
+
+// DefaultContext forwards the default context of the wrapped provider if it is a DefaultContextSupplier, and returns
+// an empty context otherwise
+func (d delegatingProvider) DefaultContext() EvaluationContext {
+	if supplier, ok := d.FeatureProvider.(DefaultContextSupplier); ok {
+		return supplier.DefaultContext()
+	}
+	return EvaluationContext{}
+}
+
+// EnrichContext forwards context enrichment to the wrapped provider if it is a ContextEnricher
+func (d delegatingProvider) EnrichContext(ctx context.Context, flat FlattenedContext) FlattenedContext {
+	if enricher, ok := d.FeatureProvider.(ContextEnricher); ok {
+		return enricher.EnrichContext(ctx, flat)
+	}
+	return flat
+}
+
+// Snapshot forwards the snapshot of the wrapped provider if it is a Snapshotter, and returns a nil provider otherwise
+func (d delegatingProvider) Snapshot(ctx context.Context) (FeatureProvider, error) {
+	if snapshotter, ok := d.FeatureProvider.(Snapshotter); ok {
+		return snapshotter.Snapshot(ctx)
+	}
+	return nil, nil
+}
+
+// DefaultFor forwards the default prescribed by the wrapped provider if it is a DefaultOverrider
+func (d delegatingProvider) DefaultFor(flagKey string, flagType Type) (interface{}, bool) {
+	if overrider, ok := d.FeatureProvider.(DefaultOverrider); ok {
+		return overrider.DefaultFor(flagKey, flagType)
+	}
+	return nil, false
+}
+
+// FlagSchema forwards the schema of the wrapped provider if it implements FlagSchema, and returns nil otherwise
+func (d delegatingProvider) FlagSchema() map[string]Type {
+	if schema, ok := d.FeatureProvider.(FlagSchema); ok {
+		return schema.FlagSchema()
+	}
+	return nil
+}
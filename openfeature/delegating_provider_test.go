@@ -0,0 +1,119 @@
+package openfeature
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDelegatingProviderForwardsCapabilities(t *testing.T) {
+	t.Run("snapshot", func(t *testing.T) {
+		enabled := true
+		provider := NewTimeoutProvider(snapshottingProvider{enabled: &enabled}, time.Second)
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+		if err := api.SetProviderAndWait(provider); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+		client := newClient(t.Name(), api, executor)
+
+		ctx, err := client.Snapshot(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		enabled = false
+		if value, _ := client.BooleanValue(ctx, "flag", false, EvaluationContext{}); !value {
+			t.Error("expected the evaluation to use the snapshot of the wrapped provider")
+		}
+	})
+
+	t.Run("default overrider", func(t *testing.T) {
+		provider := NewNormalizingProvider(defaultOverridingProvider{}, nil, nil)
+		value, ok := provider.DefaultFor("overridden", Boolean)
+		if !ok || value != true {
+			t.Errorf("expected the default of the wrapped provider, got %v, %t", value, ok)
+		}
+	})
+
+	t.Run("flag schema", func(t *testing.T) {
+		provider := NewTimeoutProvider(schemaProvider{}, time.Second)
+		if flagType, ok := provider.FlagSchema()["flag"]; !ok || flagType != String {
+			t.Errorf("expected the schema of the wrapped provider, got %v", provider.FlagSchema())
+		}
+	})
+
+	t.Run("capabilities missing from the wrapped provider", func(t *testing.T) {
+		provider := NewTimeoutProvider(NoopProvider{}, time.Second)
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+		if err := api.SetProviderAndWait(provider); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+		client := newClient(t.Name(), api, executor)
+
+		ctx := context.Background()
+		if snapshotCtx, err := client.Snapshot(ctx); err != nil || snapshotCtx != ctx {
+			t.Errorf("expected the context to be returned unchanged, got %v", err)
+		}
+		if _, ok := provider.DefaultFor("flag", Boolean); ok {
+			t.Error("expected no default")
+		}
+		if _, err := client.EvaluateByPrefix(ctx, "", EvaluationContext{}); !errors.Is(err, ErrOperationNotSupported) {
+			t.Errorf("expected an unsupported operation error, got %v", err)
+		}
+	})
+}
+
+func TestDecoratedProviderLifecycle(t *testing.T) {
+	plain := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		return true, ProviderResolutionDetail{Reason: StaticReason}
+	})
+	initializing := struct {
+		FeatureProvider
+		StateHandler
+	}{plain, &stateHandlerForTests{}}
+
+	decorators := map[string]func(FeatureProvider) FeatureProvider{
+		"timeout": func(p FeatureProvider) FeatureProvider { return NewTimeoutProvider(p, time.Second) },
+		"normalizing": func(p FeatureProvider) FeatureProvider {
+			return NewNormalizingProvider(p, nil, nil)
+		},
+		"shadow":           func(p FeatureProvider) FeatureProvider { return NewShadowProvider(p, NoopProvider{}, nil) },
+		"override":         func(p FeatureProvider) FeatureProvider { return NewOverrideProvider(p) },
+		"shutdown timeout": func(p FeatureProvider) FeatureProvider { return newShutdownTimeoutProvider(p, time.Second) },
+	}
+
+	for name, decorate := range decorators {
+		t.Run(name, func(t *testing.T) {
+			executor := newEventExecutor()
+			api := newEvaluationAPI(executor)
+			if err := api.SetNamedProvider(t.Name(), decorate(plain), true); err != nil {
+				t.Fatalf("error setting up provider %v", err)
+			}
+			client := newClient(t.Name(), api, executor)
+
+			// no initialization to wait for
+			if value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}); err != nil || !value {
+				t.Errorf("expected the wrapped provider to be ready right away, got %t and %v", value, err)
+			}
+			if !requiresInitialization(decorate(initializing)) {
+				t.Error("expected the initialization of the wrapped provider to be required")
+			}
+		})
+	}
+
+	t.Run("events", func(t *testing.T) {
+		executor := newEventExecutor()
+		if err := executor.registerDefaultProvider(NewTimeoutProvider(plain, time.Second)); err != nil {
+			t.Fatalf("error registering provider %v", err)
+		}
+		if explanation := executor.explainHandlerRouting(defaultDomain, ProviderConfigChange); !strings.Contains(explanation, "does not implement EventHandler") {
+			t.Errorf("expected the wrapped provider not to emit events, got %s", explanation)
+		}
+		if !emitsEvents(NewOverrideProvider(plain)) {
+			t.Error("expected the override provider to emit override changes")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package openfeature
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/open-feature/go-sdk/openfeature/internal"
 )
@@ -92,3 +93,59 @@ func TransactionContext(ctx context.Context) EvaluationContext {
 
 	return ec
 }
+
+// ContextLevel identifies the level at which an evaluation context is set, see SetContextMergePrecedence
+type ContextLevel int
+
+const (
+	// APIContextLevel is the level of the global evaluation context
+	APIContextLevel ContextLevel = iota
+	// TransactionContextLevel is the level of the transaction context, propagated with context.Context
+	TransactionContextLevel
+	// ClientContextLevel is the level of the client evaluation context
+	ClientContextLevel
+	// InvocationContextLevel is the level of the evaluation context given to an evaluation
+	InvocationContextLevel
+)
+
+// DefaultContextMergePrecedence returns the merge precedence defined by the specification, from lowest to highest:
+// API, transaction, client, invocation. The returned slice is a copy, set the precedence with
+// SetContextMergePrecedence.
+func DefaultContextMergePrecedence() []ContextLevel {
+	return append([]ContextLevel(nil), defaultContextMergePrecedence...)
+}
+
+// defaultContextMergePrecedence is the merge precedence defined by the specification
+var defaultContextMergePrecedence = []ContextLevel{
+	APIContextLevel, TransactionContextLevel, ClientContextLevel, InvocationContextLevel,
+}
+
+// validateContextMergePrecedence checks that the precedence holds each context level exactly once
+func validateContextMergePrecedence(precedence []ContextLevel) error {
+	if len(precedence) != len(defaultContextMergePrecedence) {
+		return fmt.Errorf("merge precedence must hold %d context levels, got %d", len(defaultContextMergePrecedence), len(precedence))
+	}
+
+	seen := make(map[ContextLevel]bool, len(precedence))
+	for _, level := range precedence {
+		if level < APIContextLevel || level > InvocationContextLevel {
+			return fmt.Errorf("unknown context level %d", level)
+		}
+		if seen[level] {
+			return fmt.Errorf("duplicate context level %d", level)
+		}
+		seen[level] = true
+	}
+
+	return nil
+}
+
+// mergeContextLevels merges the evaluation contexts of each level, from the lowest to the highest precedence
+func mergeContextLevels(precedence []ContextLevel, levels map[ContextLevel]EvaluationContext) EvaluationContext {
+	// mergeContexts gives precedence to the first context, so walk from the highest precedence
+	ordered := make([]EvaluationContext, 0, len(precedence))
+	for i := len(precedence) - 1; i >= 0; i-- {
+		ordered = append(ordered, levels[precedence[i]])
+	}
+	return mergeContexts(ordered...)
+}
@@ -250,3 +250,68 @@ func TestMergeTransactionContext(t *testing.T) {
 		)
 	}
 }
+
+func TestSetContextMergePrecedence(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	client := newClient(t.Name(), api, executor)
+	if err := api.SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	var hookCtx EvaluationContext
+	api.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"level": "api"}))
+	client.SetEvaluationContext(NewEvaluationContext("", map[string]interface{}{"level": "client"}))
+	ctx := WithTransactionContext(context.Background(), NewEvaluationContext("", map[string]interface{}{"level": "transaction"}))
+	invocationCtx := NewEvaluationContext("", map[string]interface{}{"level": "invocation"})
+
+	evaluateLevel := func() interface{} {
+		_, err := client.BooleanValue(ctx, "flag", false, invocationCtx, WithHooks(contextCapturingHook{seen: &hookCtx}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return hookCtx.Attribute("level")
+	}
+
+	if level := evaluateLevel(); level != "invocation" {
+		t.Errorf("expected the invocation context to take precedence by default, got %v", level)
+	}
+
+	// neither the default nor the returned precedence alias the precedence in use
+	defaultPrecedence := DefaultContextMergePrecedence()
+	defaultPrecedence[2], defaultPrecedence[3] = InvocationContextLevel, ClientContextLevel
+	api.ContextMergePrecedence()[3] = APIContextLevel
+	if level := evaluateLevel(); level != "invocation" {
+		t.Errorf("expected the precedence in use to be unaffected, got %v", level)
+	}
+	if level := DefaultContextMergePrecedence()[3]; level != InvocationContextLevel {
+		t.Errorf("expected the default precedence to be unaffected, got %v", level)
+	}
+
+	err := api.SetContextMergePrecedence([]ContextLevel{
+		APIContextLevel, TransactionContextLevel, InvocationContextLevel, ClientContextLevel,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if level := evaluateLevel(); level != "client" {
+		t.Errorf("expected the client context to take precedence, got %v", level)
+	}
+
+	invalid := map[string][]ContextLevel{
+		"missing level":   {APIContextLevel, ClientContextLevel, InvocationContextLevel},
+		"duplicate level": {APIContextLevel, APIContextLevel, ClientContextLevel, InvocationContextLevel},
+		"unknown level":   {APIContextLevel, TransactionContextLevel, ClientContextLevel, ContextLevel(42)},
+	}
+	for name, precedence := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := api.SetContextMergePrecedence(precedence); err == nil {
+				t.Error("expected an error for an invalid merge precedence")
+			}
+			if level := evaluateLevel(); level != "client" {
+				t.Errorf("expected the previous precedence to be kept, got %v", level)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package openfeature
+
+// EvaluationEvent describes a completed flag evaluation, see SubscribeEvaluations
+type EvaluationEvent struct {
+	// Domain is the domain of the evaluating client
+	Domain string
+	// Details holds the details of the evaluation, as returned to the caller
+	Details InterfaceEvaluationDetails
+	// Error is the error of the evaluation, if it failed
+	Error error
+}
+
+// evaluationSubscriber is a subscriber to evaluation events, identified for unsubscription
+type evaluationSubscriber struct {
+	id       uint64
+	callback func(EvaluationEvent)
+}
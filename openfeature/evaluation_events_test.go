@@ -0,0 +1,41 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubscribeEvaluations(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetNamedProviderAndWaitContext(context.Background(), "named", notFoundProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient("named", api, executor)
+
+	var events []EvaluationEvent
+	unsubscribe := api.SubscribeEvaluations(func(event EvaluationEvent) {
+		events = append(events, event)
+	})
+
+	client.Boolean(context.Background(), "missing", false, EvaluationContext{})
+	client.String(context.Background(), "flag", "default", EvaluationContext{})
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if event := events[0]; event.Domain != "named" || event.Details.FlagKey != "missing" || event.Details.ErrorCode != FlagNotFoundCode || event.Error == nil {
+		t.Errorf("expected a failed evaluation of flag missing, got %+v", event)
+	}
+	if event := events[1]; event.Details.FlagKey != "flag" || event.Details.FlagType != String || event.Details.Value != "default" || event.Error != nil {
+		t.Errorf("expected a successful evaluation of flag flag, got %+v", event)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	client.Boolean(context.Background(), "missing", false, EvaluationContext{})
+
+	if len(events) != 2 {
+		t.Errorf("expected no event once unsubscribed, got %d events", len(events))
+	}
+}
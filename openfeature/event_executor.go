@@ -1,8 +1,10 @@
 package openfeature
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -43,8 +45,13 @@ type eventExecutor struct {
 	apiRegistry              map[EventType][]EventCallback
 	scopedRegistry           map[string]scopedCallback
 	eventChan                chan eventPayload
+	historySize              int
+	history                  map[EventType][]Event
 	once                     sync.Once
 	mu                       sync.Mutex
+
+	stateMu      sync.Mutex
+	stateChanged chan struct{}
 }
 
 func newEventExecutor() *eventExecutor {
@@ -55,6 +62,8 @@ func newEventExecutor() *eventExecutor {
 		apiRegistry:            map[EventType][]EventCallback{},
 		scopedRegistry:         map[string]scopedCallback{},
 		eventChan:              make(chan eventPayload, 5),
+		history:                map[EventType][]Event{},
+		stateChanged:           make(chan struct{}),
 	}
 
 	executor.startEventListener()
@@ -105,6 +114,52 @@ func (e *eventExecutor) AddHandler(t EventType, c EventCallback) {
 	e.emitOnRegistration(defaultDomain, e.defaultProviderReference, t, c)
 }
 
+// AddHandlerWithHistory adds an API(global) level handler, and replays to it the retained events of its type, oldest
+// first. If no event of the type is retained, the handler is run as with AddHandler if the provider state matches.
+func (e *eventExecutor) AddHandlerWithHistory(t EventType, c EventCallback) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.apiRegistry[t] = append(e.apiRegistry[t], c)
+
+	history := e.history[t]
+	if len(history) == 0 {
+		e.emitOnRegistration(defaultDomain, e.defaultProviderReference, t, c)
+		return
+	}
+
+	// replay in a single goroutine, so that the handler receives the events in order
+	replay := append([]Event{}, history...)
+	go func() {
+		for _, event := range replay {
+			runHandler(*c, event)
+		}
+	}()
+}
+
+// SetEventHistory sets the number of most recent events retained per event type, for AddHandlerWithHistory.
+// A size of 0 disables the retention.
+func (e *eventExecutor) SetEventHistory(size int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.historySize = size
+	for t, events := range e.history {
+		e.history[t] = lastEvents(events, size)
+	}
+}
+
+// lastEvents returns the size last events
+func lastEvents(events []Event, size int) []Event {
+	if size <= 0 {
+		return nil
+	}
+	if len(events) > size {
+		return events[len(events)-size:]
+	}
+	return events
+}
+
 // RemoveHandler removes an API(global) level handler
 func (e *eventExecutor) RemoveHandler(t EventType, c EventCallback) {
 	e.mu.Lock()
@@ -194,16 +249,7 @@ func (e *eventExecutor) emitOnRegistration(domain string, providerReference prov
 		return
 	}
 
-	var message string
-	if state == ReadyState && eventType == ProviderReady {
-		message = "provider is in ready state"
-	} else if state == ErrorState && eventType == ProviderError {
-		message = "provider is in error state"
-	} else if state == StaleState && eventType == ProviderStale {
-		message = "provider is in stale state"
-	}
-
-	if message != "" {
+	if message := registrationMessage(state, eventType); message != "" {
 		(*callback)(EventDetails{
 			ProviderName: providerReference.featureProvider.Metadata().Name,
 			ProviderEventDetails: ProviderEventDetails{
@@ -213,6 +259,67 @@ func (e *eventExecutor) emitOnRegistration(domain string, providerReference prov
 	}
 }
 
+// registrationMessage returns the message of the event run on registration of a handler of the given event type, or
+// an empty string if the state does not match the event type
+func registrationMessage(state State, eventType EventType) string {
+	switch {
+	case state == ReadyState && eventType == ProviderReady:
+		return "provider is in ready state"
+	case state == ErrorState && eventType == ProviderError:
+		return "provider is in error state"
+	case state == StaleState && eventType == ProviderStale:
+		return "provider is in stale state"
+	default:
+		return ""
+	}
+}
+
+// explainHandlerRouting describes how events of the given type reach the handlers of the domain: the provider bound to
+// the domain, whether it emits events, the state of the domain and the registered handlers
+func (e *eventExecutor) explainHandlerRouting(domain string, eventType EventType) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "routing of %s events for domain %q:\n", eventType, domain)
+
+	reference, named := e.namedProviderReference[domain]
+	switch {
+	case named && domain != defaultDomain:
+		fmt.Fprintf(&b, "- provider %q is bound to the domain\n", reference.featureProvider.Metadata().Name)
+	case e.defaultProviderReference.featureProvider != nil:
+		reference = e.defaultProviderReference
+		fmt.Fprintf(&b, "- no provider is bound to the domain, it uses the default provider %q\n",
+			reference.featureProvider.Metadata().Name)
+	default:
+		b.WriteString("- no provider is set for the domain nor as default, no event is emitted\n")
+	}
+
+	if reference.featureProvider != nil {
+		if emitsEvents(reference.featureProvider) {
+			b.WriteString("- the provider emits its own events through its event channel\n")
+		} else {
+			fmt.Fprintf(&b, "- the provider does not implement EventHandler, only %s or %s is emitted on its initialization\n",
+				ProviderReady, ProviderError)
+		}
+	}
+
+	if state, ok := e.loadState(domain); ok {
+		fmt.Fprintf(&b, "- the domain is in %s state", state)
+		if registrationMessage(state, eventType) != "" {
+			b.WriteString(", handlers added now run immediately")
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString("- the domain has no state yet, the provider did not report any\n")
+	}
+
+	fmt.Fprintf(&b, "- %d API handler(s) registered, run for events of any provider\n", len(e.apiRegistry[eventType]))
+	fmt.Fprintf(&b, "- %d client handler(s) registered for the domain", len(e.scopedRegistry[domain].callbacks[eventType]))
+
+	return b.String()
+}
+
 func (e *eventExecutor) loadState(domain string) (State, bool) {
 	state, ok := e.states.Load(domain)
 	if !ok {
@@ -228,6 +335,53 @@ func (e *eventExecutor) State(domain string) State {
 	return state
 }
 
+// storeState stores the state of the domain and notifies the state waiters
+func (e *eventExecutor) storeState(domain string, state State) {
+	e.states.Store(domain, state)
+
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	close(e.stateChanged)
+	e.stateChanged = make(chan struct{})
+}
+
+// deleteState deletes the state of the domain, which then reports the state of the default domain, and notifies the
+// state waiters
+func (e *eventExecutor) deleteState(domain string) {
+	e.states.Delete(domain)
+
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	close(e.stateChanged)
+	e.stateChanged = make(chan struct{})
+}
+
+// stateChanges returns a channel closed on the next state change of any domain
+func (e *eventExecutor) stateChanges() <-chan struct{} {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	return e.stateChanged
+}
+
+// waitForState waits until the state of the domain satisfies accept, or ctx is done.
+// Returns the last observed state.
+func (e *eventExecutor) waitForState(ctx context.Context, domain string, accept func(State) bool) (State, error) {
+	for {
+		// subscribe before reading the state, so that no change is missed in between
+		changed := e.stateChanges()
+		state := e.State(domain)
+		if accept(state) {
+			return state, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return state, ctx.Err()
+		}
+	}
+}
+
 // registerDefaultProvider registers the default FeatureProvider and remove the old default provider if available
 func (e *eventExecutor) registerDefaultProvider(provider FeatureProvider) error {
 	e.mu.Lock()
@@ -276,7 +430,7 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 
 		go func() {
 			v, ok := newProvider.featureProvider.(EventHandler)
-			if !ok {
+			if !ok || !emitsEvents(newProvider.featureProvider) {
 				return
 			}
 
@@ -295,8 +449,27 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 		}()
 	}
 
-	// shutdown old provider handling
+	return e.stopListening(oldReference)
+}
+
+// unregisterNamedEventingProvider unbinds the provider of the domain, which falls back to the default provider, and
+// removes the event listener of the provider unless it is bound elsewhere
+func (e *eventExecutor) unregisterNamedEventingProvider(associatedClient string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	oldProvider, ok := e.namedProviderReference[associatedClient]
+	if !ok {
+		return nil
+	}
+	delete(e.namedProviderReference, associatedClient)
+	e.deleteState(associatedClient)
+
+	return e.stopListening(oldProvider)
+}
 
+// stopListening stops the event listener of the old provider, if it's not bound by another subscription
+func (e *eventExecutor) stopListening(oldReference providerReference) error {
 	// check if this provider is still bound - 1:N binding capability
 	if isBound(oldReference, e.defaultProviderReference, maps.Values(e.namedProviderReference)) {
 		return nil
@@ -304,13 +477,12 @@ func (e *eventExecutor) startListeningAndShutdownOld(newProvider providerReferen
 
 	// drop from active references
 	for i, r := range e.activeSubscriptions {
-		if reflect.DeepEqual(oldReference.featureProvider, r.featureProvider) {
+		if sameProvider(oldReference.featureProvider, r.featureProvider) {
 			e.activeSubscriptions = append(e.activeSubscriptions[:i], e.activeSubscriptions[i+1:]...)
 		}
 	}
 
-	_, ok := oldReference.featureProvider.(EventHandler)
-	if !ok {
+	if !emitsEvents(oldReference.featureProvider) {
 		// no shutdown for non event handling provider
 		return nil
 	}
@@ -341,30 +513,41 @@ func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	// API handlers get the metadata of the default binding if the event is one of the default provider
+	apiEvent := tagEvent(handler, event)
+	if sameProvider(e.defaultProviderReference.featureProvider, handler) {
+		apiEvent = tagEvent(e.defaultProviderReference.featureProvider, event)
+	}
+
+	if e.historySize > 0 {
+		e.history[event.EventType] = lastEvents(append(e.history[event.EventType], apiEvent), e.historySize)
+	}
+
 	// first run API handlers
 	for _, c := range e.apiRegistry[event.EventType] {
-		e.executeHandler(*c, event)
+		e.executeHandler(*c, apiEvent)
 	}
 
 	// then run client handlers
 	for domain, reference := range e.namedProviderReference {
-		if !reflect.DeepEqual(reference.featureProvider, handler) {
+		if !sameProvider(reference.featureProvider, handler) {
 			// unassociated client, continue to next
 			continue
 		}
 
-		e.states.Store(domain, stateFromEvent(event))
+		e.storeState(domain, stateFromEvent(event))
 		for _, c := range e.scopedRegistry[domain].callbacks[event.EventType] {
-			e.executeHandler(*c, event)
+			e.executeHandler(*c, tagEvent(reference.featureProvider, event))
 		}
 	}
 
-	if !reflect.DeepEqual(e.defaultProviderReference.featureProvider, handler) {
+	if !sameProvider(e.defaultProviderReference.featureProvider, handler) {
 		return
 	}
 
 	// handling the default provider
-	e.states.Store(defaultDomain, stateFromEvent(event))
+	event = tagEvent(e.defaultProviderReference.featureProvider, event)
+	e.storeState(defaultDomain, stateFromEvent(event))
 	// invoke default provider bound (no provider associated) handlers by filtering
 	for domain, registry := range e.scopedRegistry {
 		if _, ok := e.namedProviderReference[domain]; ok {
@@ -381,28 +564,32 @@ func (e *eventExecutor) triggerEvent(event Event, handler FeatureProvider) {
 
 // executeHandler is a helper which performs the actual invocation of the callback
 func (e *eventExecutor) executeHandler(f func(details EventDetails), event Event) {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Info("recovered from a panic")
-			}
-		}()
+	go runHandler(f, event)
+}
 
-		f(EventDetails{
-			ProviderName: event.ProviderName,
-			ProviderEventDetails: ProviderEventDetails{
-				Message:       event.Message,
-				FlagChanges:   event.FlagChanges,
-				EventMetadata: event.EventMetadata,
-			},
-		})
+// runHandler invokes the callback with the details of the event, recovering from panics
+func runHandler(f func(details EventDetails), event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Info("recovered from a panic")
+		}
 	}()
+
+	f(EventDetails{
+		ProviderName: event.ProviderName,
+		ProviderEventDetails: ProviderEventDetails{
+			Message:           event.Message,
+			FlagChanges:       event.FlagChanges,
+			FlagChangeDetails: event.FlagChangeDetails,
+			EventMetadata:     event.EventMetadata,
+		},
+	})
 }
 
 // isRunning is a helper till we bump to the latest go version with slices.contains support
 func isRunning(provider providerReference, activeProviders []providerReference) bool {
 	for _, activeProvider := range activeProviders {
-		if reflect.DeepEqual(activeProvider.featureProvider, provider.featureProvider) {
+		if sameProvider(activeProvider.featureProvider, provider.featureProvider) {
 			return true
 		}
 	}
@@ -412,15 +599,20 @@ func isRunning(provider providerReference, activeProviders []providerReference)
 
 // isRunning is a helper to check if given provider is already in use
 func isBound(provider providerReference, defaultProvider providerReference, namedProviders []providerReference) bool {
-	if reflect.DeepEqual(provider.featureProvider, defaultProvider.featureProvider) {
+	if sameProvider(provider.featureProvider, defaultProvider.featureProvider) {
 		return true
 	}
 
 	for _, namedProvider := range namedProviders {
-		if reflect.DeepEqual(provider.featureProvider, namedProvider.featureProvider) {
+		if sameProvider(provider.featureProvider, namedProvider.featureProvider) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// sameProvider reports whether a and b are the same provider, seeing through the decorator of SetProviderWithMetadata
+func sameProvider(a, b FeatureProvider) bool {
+	return reflect.DeepEqual(untagged(a), untagged(b))
+}
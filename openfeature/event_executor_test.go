@@ -3,6 +3,7 @@ package openfeature
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,6 +83,9 @@ func TestEventHandler_Eventing(t *testing.T) {
 		AddHandler(eventType, &callBack)
 
 		fCh := []string{"flagA"}
+		fChDetails := map[string]FlagChange{
+			"flagA": {Type: FlagModified, OldVariant: "off", NewVariant: "on"},
+		}
 		meta := map[string]interface{}{
 			"key": "value",
 		}
@@ -90,9 +94,10 @@ func TestEventHandler_Eventing(t *testing.T) {
 		eventingImpl.Invoke(Event{
 			EventType: eventType,
 			ProviderEventDetails: ProviderEventDetails{
-				Message:       "ReadyMessage",
-				FlagChanges:   fCh,
-				EventMetadata: meta,
+				Message:           "ReadyMessage",
+				FlagChanges:       fCh,
+				FlagChangeDetails: fChDetails,
+				EventMetadata:     meta,
 			},
 		})
 
@@ -112,6 +117,10 @@ func TestEventHandler_Eventing(t *testing.T) {
 			t.Errorf("flag changes are not equal")
 		}
 
+		if !reflect.DeepEqual(result.FlagChangeDetails, fChDetails) {
+			t.Errorf("flag change details are not equal")
+		}
+
 		if !reflect.DeepEqual(result.EventMetadata, meta) {
 			t.Errorf("metadata are not equal")
 		}
@@ -1550,3 +1559,110 @@ func TestEventHandler_APIRemoval(t *testing.T) {
 		executor.RemoveClientHandler("a", ProviderReady, &h1)
 	})
 }
+
+func TestEventHistory(t *testing.T) {
+	executor := newEventExecutor()
+	executor.SetEventHistory(2)
+
+	for _, message := range []string{"first", "second", "third"} {
+		executor.triggerEvent(Event{
+			EventType:            ProviderConfigChange,
+			ProviderEventDetails: ProviderEventDetails{Message: message},
+		}, NoopProvider{})
+	}
+	executor.triggerEvent(Event{EventType: ProviderStale}, NoopProvider{})
+
+	received := make(chan string, 3)
+	callback := func(details EventDetails) {
+		received <- details.Message
+	}
+	executor.AddHandlerWithHistory(ProviderConfigChange, &callback)
+
+	for _, want := range []string{"second", "third"} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Errorf("expected replayed event %q, got %q", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected replayed event %q", want)
+		}
+	}
+	select {
+	case got := <-received:
+		t.Errorf("expected only the retained events to be replayed, got %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	executor.SetEventHistory(0)
+	if len(executor.history[ProviderConfigChange]) != 0 {
+		t.Errorf("expected disabling the history to discard retained events")
+	}
+}
+
+func TestExplainHandlerRouting(t *testing.T) {
+	executor := newEventExecutor()
+
+	t.Run("no provider", func(t *testing.T) {
+		explanation := executor.explainHandlerRouting("checkout", ProviderReady)
+		if !strings.Contains(explanation, "no provider is set") {
+			t.Errorf("expected the missing provider to be explained, got:\n%s", explanation)
+		}
+	})
+
+	defaultProvider := struct {
+		FeatureProvider
+		EventHandler
+	}{NoopProvider{}, &ProviderEventing{c: make(chan Event)}}
+	if err := executor.registerDefaultProvider(defaultProvider); err != nil {
+		t.Fatal(err)
+	}
+	if err := executor.registerNamedEventingProvider("checkout", NoopProvider{}); err != nil {
+		t.Fatal(err)
+	}
+	executor.storeState("checkout", ReadyState)
+	callback := func(EventDetails) {}
+	executor.AddClientHandler("checkout", ProviderReady, &callback)
+
+	tests := map[string]struct {
+		domain    string
+		eventType EventType
+		want      []string
+	}{
+		"bound provider without eventing": {
+			domain:    "checkout",
+			eventType: ProviderReady,
+			want: []string{
+				`provider "NoopProvider" is bound to the domain`,
+				"does not implement EventHandler",
+				"READY state, handlers added now run immediately",
+				"1 client handler(s)",
+			},
+		},
+		"state not matching the event type": {
+			domain:    "checkout",
+			eventType: ProviderStale,
+			want:      []string{"READY state\n", "0 client handler(s)"},
+		},
+		"domain using the default provider": {
+			domain:    "search",
+			eventType: ProviderConfigChange,
+			want: []string{
+				"it uses the default provider",
+				"emits its own events",
+				"0 API handler(s)",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			explanation := executor.explainHandlerRouting(test.domain, test.eventType)
+			for _, want := range test.want {
+				if !strings.Contains(explanation, want) {
+					t.Errorf("expected explanation to contain %q, got:\n%s", want, explanation)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResolveFunc resolves a flag of any type, see NewFuncProvider
+type ResolveFunc func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail)
+
+// FuncProvider is a FeatureProvider backed by a single ResolveFunc, see NewFuncProvider
+type FuncProvider struct {
+	resolve ResolveFunc
+}
+
+// NewFuncProvider adapts a resolution function into a FeatureProvider, e.g. to stand up a custom provider in a few
+// lines for prototypes and tests. Each typed evaluation calls resolve with the type of the flag. A value returned
+// along with a resolution error is ignored in favor of the default value. A value not of the type of the flag (bool,
+// string, float64, int64 or int, any value for object flags) resolves to the default value with a TYPE_MISMATCH
+// error.
+func NewFuncProvider(resolve ResolveFunc) FuncProvider {
+	return FuncProvider{resolve: resolve}
+}
+
+// Metadata returns the metadata of the provider
+func (p FuncProvider) Metadata() Metadata {
+	return Metadata{Name: "FuncProvider"}
+}
+
+// BooleanEvaluation resolves a boolean flag with the resolution function
+func (p FuncProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	value, detail := funcResolve(ctx, p.resolve, flag, Boolean, defaultValue, evalCtx)
+	return BoolResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+// StringEvaluation resolves a string flag with the resolution function
+func (p FuncProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	value, detail := funcResolve(ctx, p.resolve, flag, String, defaultValue, evalCtx)
+	return StringResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+// FloatEvaluation resolves a float flag with the resolution function
+func (p FuncProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	value, detail := funcResolve(ctx, p.resolve, flag, Float, defaultValue, evalCtx)
+	return FloatResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+// IntEvaluation resolves an int flag with the resolution function
+func (p FuncProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	value, detail := p.resolve(ctx, flag, Int, defaultValue, evalCtx)
+	if i, ok := value.(int); ok && detail.Error() == nil {
+		return IntResolutionDetail{Value: int64(i), ProviderResolutionDetail: detail}
+	}
+	resolved, detail := typedResolution(flag, Int, value, defaultValue, detail)
+	return IntResolutionDetail{Value: resolved, ProviderResolutionDetail: detail}
+}
+
+// ObjectEvaluation resolves an object flag with the resolution function
+func (p FuncProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	value, detail := p.resolve(ctx, flag, Object, defaultValue, evalCtx)
+	if detail.Error() != nil {
+		value = defaultValue
+	}
+	return InterfaceResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+// Hooks returns no hooks
+func (p FuncProvider) Hooks() []Hook {
+	return []Hook{}
+}
+
+// funcResolve resolves a flag with the resolution function, checking the type of the resolved value
+func funcResolve[T any](
+	ctx context.Context, resolve ResolveFunc, flag string, flagType Type, defaultValue T, evalCtx FlattenedContext,
+) (T, ProviderResolutionDetail) {
+	value, detail := resolve(ctx, flag, flagType, defaultValue, evalCtx)
+	return typedResolution(flag, flagType, value, defaultValue, detail)
+}
+
+// typedResolution returns the resolved value if it is of the flag type and the resolution succeeded, otherwise the
+// default value
+func typedResolution[T any](flag string, flagType Type, value interface{}, defaultValue T, detail ProviderResolutionDetail) (T, ProviderResolutionDetail) {
+	if detail.Error() != nil {
+		return defaultValue, detail
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return defaultValue, ProviderResolutionDetail{
+			ResolutionError: NewTypeMismatchResolutionError(
+				fmt.Sprintf("flag %s resolved to %T, expected %s", flag, value, flagType),
+			),
+			Reason: ErrorReason,
+		}
+	}
+	return typed, detail
+}
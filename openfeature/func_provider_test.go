@@ -0,0 +1,54 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFuncProvider(t *testing.T) {
+	provider := NewFuncProvider(func(_ context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		switch flag {
+		case "missing":
+			return nil, ProviderResolutionDetail{
+				ResolutionError: NewFlagNotFoundResolutionError("not found"),
+				Reason:          ErrorReason,
+			}
+		case "user":
+			return evalCtx[TargetingKey], ProviderResolutionDetail{Reason: TargetingMatchReason}
+		case "count":
+			return 3, ProviderResolutionDetail{Reason: StaticReason}
+		default:
+			return true, ProviderResolutionDetail{Reason: StaticReason, Variant: "on"}
+		}
+	})
+
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	ctx := context.Background()
+
+	if details, err := client.BooleanValueDetails(ctx, "enabled", false, EvaluationContext{}); err != nil || !details.Value || details.Variant != "on" {
+		t.Errorf("unexpected boolean evaluation %+v, %v", details, err)
+	}
+	if value, err := client.StringValue(ctx, "user", "", NewEvaluationContext("alice", nil)); err != nil || value != "alice" {
+		t.Errorf("unexpected string evaluation %q, %v", value, err)
+	}
+	if value, err := client.IntValue(ctx, "count", 0, EvaluationContext{}); err != nil || value != 3 {
+		t.Errorf("unexpected int evaluation %d, %v", value, err)
+	}
+	if value, err := client.ObjectValue(ctx, "enabled", nil, EvaluationContext{}); err != nil || value != true {
+		t.Errorf("unexpected object evaluation %v, %v", value, err)
+	}
+
+	details, err := client.FloatValueDetails(ctx, "enabled", 1.5, EvaluationContext{})
+	if err == nil || details.Value != 1.5 || details.ErrorCode != TypeMismatchCode {
+		t.Errorf("expected a type mismatch, got %+v, %v", details, err)
+	}
+
+	details, err = client.FloatValueDetails(ctx, "missing", 1.5, EvaluationContext{})
+	if err == nil || details.Value != 1.5 || details.ErrorCode != FlagNotFoundCode {
+		t.Errorf("expected a flag not found error, got %+v, %v", details, err)
+	}
+}
@@ -1,6 +1,9 @@
 package openfeature
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Hook allows application developers to add arbitrary behavior to the flag evaluation lifecycle.
 // They operate similarly to middleware in many web frameworks.
@@ -88,6 +91,60 @@ func NewHookContext(
 	}
 }
 
+// HookStage is a stage of the flag evaluation life-cycle at which hooks run
+type HookStage string
+
+// HookScope is the level at which a hook was registered
+type HookScope string
+
+const (
+	BeforeHookStage  HookStage = "before"
+	AfterHookStage   HookStage = "after"
+	ErrorHookStage   HookStage = "error"
+	FinallyHookStage HookStage = "finally"
+
+	APIHookScope        HookScope = "API"
+	ClientHookScope     HookScope = "Client"
+	InvocationHookScope HookScope = "Invocation"
+	ProviderHookScope   HookScope = "Provider"
+)
+
+// HookTraceEvent describes a single hook invocation during a flag evaluation.
+// See WithHookTracing.
+type HookTraceEvent struct {
+	FlagKey  string
+	Stage    HookStage
+	Scope    HookScope
+	HookType string
+	Duration time.Duration
+	// Err is the error returned by the hook, for the stages which can return one
+	Err error
+}
+
+// scopedHook is a Hook along with the scope it was registered at
+type scopedHook struct {
+	Hook
+	scope HookScope
+}
+
+// scopeHooks associates the given hooks with a scope
+func scopeHooks(scope HookScope, hooks []Hook) []scopedHook {
+	scoped := make([]scopedHook, 0, len(hooks))
+	for _, hook := range hooks {
+		scoped = append(scoped, scopedHook{Hook: hook, scope: scope})
+	}
+	return scoped
+}
+
+// concatHooks concatenates the given hook chains into a new chain
+func concatHooks(chains ...[]scopedHook) []scopedHook {
+	var hooks []scopedHook
+	for _, chain := range chains {
+		hooks = append(hooks, chain...)
+	}
+	return hooks
+}
+
 // check at compile time that UnimplementedHook implements the Hook interface
 var _ Hook = UnimplementedHook{}
 
@@ -17,22 +17,69 @@ const (
 	REASON_KEY             = "reason"
 	VARIANT_KEY            = "variant"
 	VALUE_KEY              = "value"
+	FLAG_METADATA_KEY      = "flag_metadata"
 )
 
+// REDACTED replaces the value of redacted evaluation context attributes, see WithRedactedAttributes
+const REDACTED = "[REDACTED]"
+
 type LoggingHook struct {
 	includeEvaluationContext bool
+	includeFlagMetadata      bool
+	redactedAttributes       map[string]struct{}
+	levels                   map[of.HookStage]slog.Level
 	logger                   *slog.Logger
 }
 
-func NewLoggingHook(includeEvaluationContext bool) (*LoggingHook, error) {
-	return NewCustomLoggingHook(includeEvaluationContext, slog.Default())
+// LoggingHookOption configures a LoggingHook
+type LoggingHookOption func(*LoggingHook)
+
+// WithStageLevel sets the level at which the given stage is logged. By default, the error stage is logged at the error
+// level and the other stages at the debug level.
+func WithStageLevel(stage of.HookStage, level slog.Level) LoggingHookOption {
+	return func(h *LoggingHook) {
+		h.levels[stage] = level
+	}
+}
+
+// WithRedactedAttributes replaces the value of the given evaluation context attributes with REDACTED when the
+// evaluation context is logged, e.g. for personal data. The targeting key is redacted with the TargetingKey attribute
+// name.
+func WithRedactedAttributes(attributes ...string) LoggingHookOption {
+	return func(h *LoggingHook) {
+		for _, attribute := range attributes {
+			h.redactedAttributes[attribute] = struct{}{}
+		}
+	}
+}
+
+// WithFlagMetadata includes the flag metadata returned by the provider in the logs of the after stage
+func WithFlagMetadata() LoggingHookOption {
+	return func(h *LoggingHook) {
+		h.includeFlagMetadata = true
+	}
 }
 
-func NewCustomLoggingHook(includeEvaluationContext bool, logger *slog.Logger) (*LoggingHook, error) {
-	return &LoggingHook{
+func NewLoggingHook(includeEvaluationContext bool, options ...LoggingHookOption) (*LoggingHook, error) {
+	return NewCustomLoggingHook(includeEvaluationContext, slog.Default(), options...)
+}
+
+func NewCustomLoggingHook(includeEvaluationContext bool, logger *slog.Logger, options ...LoggingHookOption) (*LoggingHook, error) {
+	hook := &LoggingHook{
 		logger:                   logger,
 		includeEvaluationContext: includeEvaluationContext,
-	}, nil
+		redactedAttributes:       map[string]struct{}{},
+		levels: map[of.HookStage]slog.Level{
+			of.BeforeHookStage:  slog.LevelDebug,
+			of.AfterHookStage:   slog.LevelDebug,
+			of.ErrorHookStage:   slog.LevelError,
+			of.FinallyHookStage: slog.LevelDebug,
+		},
+	}
+	for _, option := range options {
+		option(hook)
+	}
+	return hook, nil
 }
 
 type MarshaledEvaluationContext struct {
@@ -49,23 +96,43 @@ func (l LoggingHook) buildArgs(hookContext of.HookContext) ([]interface{}, error
 		DEFAULT_VALUE_KEY, hookContext.DefaultValue(),
 	}
 	if l.includeEvaluationContext {
-		marshaledEvaluationContext := MarshaledEvaluationContext{
-			TargetingKey: hookContext.EvaluationContext().TargetingKey(),
-			Attributes:   hookContext.EvaluationContext().Attributes(),
-		}
-		args = append(args, EVALUATION_CONTEXT_KEY, marshaledEvaluationContext)
+		args = append(args, EVALUATION_CONTEXT_KEY, l.marshalEvaluationContext(hookContext.EvaluationContext()))
 	}
 
 	return args, nil
 }
 
+// marshalEvaluationContext returns the evaluation context to log, with the redacted attributes replaced
+func (l LoggingHook) marshalEvaluationContext(evalCtx of.EvaluationContext) MarshaledEvaluationContext {
+	marshaled := MarshaledEvaluationContext{
+		TargetingKey: evalCtx.TargetingKey(),
+		Attributes:   evalCtx.Attributes(),
+	}
+	if len(l.redactedAttributes) == 0 {
+		return marshaled
+	}
+
+	if _, ok := l.redactedAttributes[of.TargetingKey]; ok && marshaled.TargetingKey != "" {
+		marshaled.TargetingKey = REDACTED
+	}
+	attributes := make(map[string]interface{}, len(marshaled.Attributes))
+	for key, value := range marshaled.Attributes {
+		if _, ok := l.redactedAttributes[key]; ok {
+			value = REDACTED
+		}
+		attributes[key] = value
+	}
+	marshaled.Attributes = attributes
+	return marshaled
+}
+
 func (h *LoggingHook) Before(ctx context.Context, hookContext of.HookContext,
 	hint of.HookHints) (*of.EvaluationContext, error) {
 	var args, err = h.buildArgs(hookContext)
 	if err != nil {
 		return nil, err
 	}
-	h.logger.Debug("Before stage", args...)
+	h.logger.Log(ctx, h.levels[of.BeforeHookStage], "Before stage", args...)
 	return nil, nil
 }
 
@@ -78,7 +145,10 @@ func (h *LoggingHook) After(ctx context.Context, hookContext of.HookContext,
 	args = append(args, REASON_KEY, flagEvaluationDetails.Reason)
 	args = append(args, VARIANT_KEY, flagEvaluationDetails.Variant)
 	args = append(args, VALUE_KEY, flagEvaluationDetails.Value)
-	h.logger.Debug("After stage", args...)
+	if h.includeFlagMetadata {
+		args = append(args, FLAG_METADATA_KEY, flagEvaluationDetails.FlagMetadata)
+	}
+	h.logger.Log(ctx, h.levels[of.AfterHookStage], "After stage", args...)
 	return nil
 }
 
@@ -88,9 +158,13 @@ func (h *LoggingHook) Error(ctx context.Context, hookContext of.HookContext, err
 		slog.Error("Error building args", "error", buildArgsErr)
 	}
 	args = append(args, ERROR_MESSAGE_KEY, err)
-	h.logger.Error("Error stage", args...)
+	h.logger.Log(ctx, h.levels[of.ErrorHookStage], "Error stage", args...)
 }
 
 func (h *LoggingHook) Finally(ctx context.Context, hCtx of.HookContext, hint of.HookHints) {
-
+	args, buildArgsErr := h.buildArgs(hCtx)
+	if buildArgsErr != nil {
+		slog.Error("Error building args", "error", buildArgsErr)
+	}
+	h.logger.Log(ctx, h.levels[of.FinallyHookStage], "Finally stage", args...)
 }
@@ -235,3 +235,68 @@ func compare(expected map[string]map[string]any, ms map[string]map[string]any, t
 		}
 	}
 }
+
+func TestLoggingHookOptions(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	hook, err := NewCustomLoggingHook(true, logger,
+		WithStageLevel(openfeature.AfterHookStage, slog.LevelInfo),
+		WithRedactedAttributes("email", openfeature.TargetingKey),
+		WithFlagMetadata(),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	provider := openfeature.NewFuncProvider(func(ctx context.Context, flag string, flagType openfeature.Type, defaultValue interface{}, evalCtx openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		return true, openfeature.ProviderResolutionDetail{
+			Reason:       openfeature.StaticReason,
+			FlagMetadata: openfeature.FlagMetadata{"owner": "checkout"},
+		}
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("error setting provider %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	client.AddHooks(hook)
+
+	evalCtx := openfeature.NewEvaluationContext("user-1", map[string]interface{}{
+		"email":   "jane@example.com",
+		"country": "fr",
+	})
+	if _, err := client.BooleanValue(context.Background(), "flag", false, evalCtx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ms := prepareOutput(buf, t)
+	if _, ok := ms["Before stage"]; ok {
+		t.Error("expected the before stage to be logged below the logger level")
+	}
+	if _, ok := ms["Finally stage"]; ok {
+		t.Error("expected the finally stage to be logged below the logger level")
+	}
+	after, ok := ms["After stage"]
+	if !ok {
+		t.Fatal("expected the after stage to be logged at the info level")
+	}
+
+	metadata, _ := after[FLAG_METADATA_KEY].(map[string]any)
+	if metadata["owner"] != "checkout" {
+		t.Errorf("expected the flag metadata to be logged, got %v", after[FLAG_METADATA_KEY])
+	}
+	evaluationContext, _ := after[EVALUATION_CONTEXT_KEY].(map[string]any)
+	if evaluationContext["TargetingKey"] != REDACTED {
+		t.Errorf("expected the targeting key to be redacted, got %v", evaluationContext["TargetingKey"])
+	}
+	attributes, _ := evaluationContext["Attributes"].(map[string]any)
+	if attributes["email"] != REDACTED {
+		t.Errorf("expected the email to be redacted, got %v", attributes["email"])
+	}
+	if attributes["country"] != "fr" {
+		t.Errorf("expected the country not to be redacted, got %v", attributes["country"])
+	}
+	if evalCtx.Attribute("email") != "jane@example.com" {
+		t.Error("expected the evaluation context not to be altered")
+	}
+}
@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	EVALUATION_REQUESTS_METRIC = "feature_flag.evaluation_requests_total"
+	EVALUATION_SUCCESS_METRIC  = "feature_flag.evaluation_success_total"
+	EVALUATION_ERRORS_METRIC   = "feature_flag.evaluation_error_total"
+	HOOK_STAGE_DURATION_METRIC = "feature_flag.hook_stage_duration"
+
+	METRIC_FLAG_KEY      = "feature_flag.key"
+	METRIC_PROVIDER_NAME = "feature_flag.provider.name"
+	METRIC_VARIANT       = "feature_flag.result.variant"
+	METRIC_REASON        = "feature_flag.result.reason"
+	METRIC_ERROR_TYPE    = "error.type"
+	METRIC_HOOK_STAGE    = "feature_flag.hook.stage"
+	METRIC_HOOK_SCOPE    = "feature_flag.hook.scope"
+	METRIC_HOOK_TYPE     = "feature_flag.hook.type"
+)
+
+// MetricsBackend records the metrics of a MetricsHook to a metrics system. See the otelhook package for an
+// OpenTelemetry implementation.
+type MetricsBackend interface {
+	// Count adds the value to the counter with the given name
+	Count(ctx context.Context, name string, value int64, attributes map[string]string)
+	// Record records the value, in seconds for durations, to the histogram with the given name
+	Record(ctx context.Context, name string, value float64, attributes map[string]string)
+}
+
+// MetricsHook counts flag evaluations: the evaluation requests, the successful evaluations by variant and reason,
+// and the failed evaluations by error code. Hook stage durations are recorded by passing RecordHookStage to
+// openfeature.WithHookTracing.
+type MetricsHook struct {
+	of.UnimplementedHook
+	backend MetricsBackend
+}
+
+func NewMetricsHook(backend MetricsBackend) *MetricsHook {
+	return &MetricsHook{backend: backend}
+}
+
+func (h *MetricsHook) Before(ctx context.Context, hookContext of.HookContext, hint of.HookHints) (*of.EvaluationContext, error) {
+	h.backend.Count(ctx, EVALUATION_REQUESTS_METRIC, 1, flagAttributes(hookContext))
+	return nil, nil
+}
+
+func (h *MetricsHook) After(ctx context.Context, hookContext of.HookContext,
+	flagEvaluationDetails of.InterfaceEvaluationDetails, hookHints of.HookHints) error {
+	attributes := flagAttributes(hookContext)
+	attributes[METRIC_VARIANT] = flagEvaluationDetails.Variant
+	attributes[METRIC_REASON] = string(flagEvaluationDetails.Reason)
+	h.backend.Count(ctx, EVALUATION_SUCCESS_METRIC, 1, attributes)
+	return nil
+}
+
+func (h *MetricsHook) Error(ctx context.Context, hookContext of.HookContext, err error, hint of.HookHints) {
+	attributes := flagAttributes(hookContext)
+	attributes[METRIC_ERROR_TYPE] = string(of.ErrorCodeOf(err))
+	h.backend.Count(ctx, EVALUATION_ERRORS_METRIC, 1, attributes)
+}
+
+// RecordHookStage records the duration of a hook stage, to measure the overhead of hooks. It is meant to be passed to
+// openfeature.WithHookTracing:
+//
+//	client.BooleanValue(ctx, "flag", false, evalCtx, openfeature.WithHookTracing(metricsHook.RecordHookStage))
+func (h *MetricsHook) RecordHookStage(event of.HookTraceEvent) {
+	h.backend.Record(context.Background(), HOOK_STAGE_DURATION_METRIC, event.Duration.Seconds(), map[string]string{
+		METRIC_FLAG_KEY:   event.FlagKey,
+		METRIC_HOOK_STAGE: string(event.Stage),
+		METRIC_HOOK_SCOPE: string(event.Scope),
+		METRIC_HOOK_TYPE:  event.HookType,
+	})
+}
+
+// flagAttributes returns the attributes identifying the evaluated flag
+func flagAttributes(hookContext of.HookContext) map[string]string {
+	return map[string]string{
+		METRIC_FLAG_KEY:      hookContext.FlagKey(),
+		METRIC_PROVIDER_NAME: hookContext.ProviderMetadata().Name,
+	}
+}
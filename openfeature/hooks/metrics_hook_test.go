@@ -0,0 +1,113 @@
+package hooks
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type recordedMetric struct {
+	name       string
+	value      float64
+	attributes map[string]string
+}
+
+type recordingBackend struct {
+	mu      sync.Mutex
+	metrics []recordedMetric
+}
+
+func (b *recordingBackend) Count(ctx context.Context, name string, value int64, attributes map[string]string) {
+	b.Record(ctx, name, float64(value), attributes)
+}
+
+func (b *recordingBackend) Record(_ context.Context, name string, value float64, attributes map[string]string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metrics = append(b.metrics, recordedMetric{name: name, value: value, attributes: attributes})
+}
+
+func (b *recordingBackend) named(name string) []recordedMetric {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var metrics []recordedMetric
+	for _, metric := range b.metrics {
+		if metric.name == name {
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
+
+func TestMetricsHook(t *testing.T) {
+	provider := openfeature.NewFuncProvider(func(ctx context.Context, flag string, flagType openfeature.Type, defaultValue interface{}, evalCtx openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		if flag == "missing" {
+			return defaultValue, openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewFlagNotFoundResolutionError("missing"),
+				Reason:          openfeature.ErrorReason,
+			}
+		}
+		return true, openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason, Variant: "on"}
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("error setting provider %v", err)
+	}
+
+	backend := &recordingBackend{}
+	hook := NewMetricsHook(backend)
+	client := openfeature.NewClient(t.Name())
+	client.AddHooks(hook)
+
+	_, _ = client.BooleanValue(context.Background(), "flag", false, openfeature.EvaluationContext{},
+		openfeature.WithHookTracing(hook.RecordHookStage))
+	_, _ = client.BooleanValue(context.Background(), "missing", false, openfeature.EvaluationContext{})
+
+	if requests := backend.named(EVALUATION_REQUESTS_METRIC); len(requests) != 2 {
+		t.Errorf("expected 2 evaluation requests, got %d", len(requests))
+	}
+
+	successes := backend.named(EVALUATION_SUCCESS_METRIC)
+	if len(successes) != 1 {
+		t.Fatalf("expected 1 successful evaluation, got %d", len(successes))
+	}
+	wantSuccess := map[string]string{
+		METRIC_FLAG_KEY:      "flag",
+		METRIC_PROVIDER_NAME: "FuncProvider",
+		METRIC_VARIANT:       "on",
+		METRIC_REASON:        string(openfeature.StaticReason),
+	}
+	for key, want := range wantSuccess {
+		if successes[0].attributes[key] != want {
+			t.Errorf("expected success attribute %s to be %q, got %q", key, want, successes[0].attributes[key])
+		}
+	}
+
+	errs := backend.named(EVALUATION_ERRORS_METRIC)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 failed evaluation, got %d", len(errs))
+	}
+	if code := errs[0].attributes[METRIC_ERROR_TYPE]; code != string(openfeature.FlagNotFoundCode) {
+		t.Errorf("expected error type %s, got %s", openfeature.FlagNotFoundCode, code)
+	}
+
+	// before, after and finally stages of the hook in the traced evaluation, ignoring API hooks of other tests
+	var durations []recordedMetric
+	for _, duration := range backend.named(HOOK_STAGE_DURATION_METRIC) {
+		if duration.attributes[METRIC_HOOK_SCOPE] == string(openfeature.ClientHookScope) {
+			durations = append(durations, duration)
+		}
+	}
+	if len(durations) != 3 {
+		t.Fatalf("expected 3 hook stage durations, got %d", len(durations))
+	}
+	for i, stage := range []openfeature.HookStage{openfeature.BeforeHookStage, openfeature.AfterHookStage, openfeature.FinallyHookStage} {
+		if durations[i].attributes[METRIC_HOOK_STAGE] != string(stage) {
+			t.Errorf("expected stage %s, got %s", stage, durations[i].attributes[METRIC_HOOK_STAGE])
+		}
+		if durations[i].value < 0 {
+			t.Errorf("expected a positive duration, got %f", durations[i].value)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package otelhook
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-feature/go-sdk/openfeature/hooks"
+)
+
+// MetricsBackend is a hooks.MetricsBackend recording to OpenTelemetry instruments: counters for counts and histograms,
+// in seconds, for recorded values. Instruments are created on first use from the meter.
+type MetricsBackend struct {
+	meter      metric.Meter
+	mu         sync.Mutex
+	counters   map[string]metric.Int64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+var _ hooks.MetricsBackend = (*MetricsBackend)(nil)
+
+// NewMetricsBackend returns a metrics backend for hooks.NewMetricsHook, recording to instruments of the given meter
+func NewMetricsBackend(meter metric.Meter) *MetricsBackend {
+	return &MetricsBackend{
+		meter:      meter,
+		counters:   map[string]metric.Int64Counter{},
+		histograms: map[string]metric.Float64Histogram{},
+	}
+}
+
+// Count adds the value to the counter with the given name
+func (b *MetricsBackend) Count(ctx context.Context, name string, value int64, attributes map[string]string) {
+	b.mu.Lock()
+	counter, ok := b.counters[name]
+	if !ok {
+		var err error
+		if counter, err = b.meter.Int64Counter(name); err != nil {
+			b.mu.Unlock()
+			otel.Handle(err)
+			return
+		}
+		b.counters[name] = counter
+	}
+	b.mu.Unlock()
+
+	counter.Add(ctx, value, metric.WithAttributes(toAttributes(attributes)...))
+}
+
+// Record records the value to the histogram with the given name
+func (b *MetricsBackend) Record(ctx context.Context, name string, value float64, attributes map[string]string) {
+	b.mu.Lock()
+	histogram, ok := b.histograms[name]
+	if !ok {
+		var err error
+		if histogram, err = b.meter.Float64Histogram(name, metric.WithUnit("s")); err != nil {
+			b.mu.Unlock()
+			otel.Handle(err)
+			return
+		}
+		b.histograms[name] = histogram
+	}
+	b.mu.Unlock()
+
+	histogram.Record(ctx, value, metric.WithAttributes(toAttributes(attributes)...))
+}
+
+// toAttributes converts the attributes of a metric to OpenTelemetry attributes
+func toAttributes(attributes map[string]string) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for key, value := range attributes {
+		kvs = append(kvs, attribute.String(key, value))
+	}
+	return kvs
+}
@@ -0,0 +1,56 @@
+package otelhook
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/open-feature/go-sdk/openfeature/hooks"
+)
+
+func TestMetricsBackend(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter(t.Name())
+	backend := NewMetricsBackend(meter)
+
+	attributes := map[string]string{hooks.METRIC_FLAG_KEY: "flag"}
+	backend.Count(context.Background(), hooks.EVALUATION_REQUESTS_METRIC, 1, attributes)
+	backend.Count(context.Background(), hooks.EVALUATION_REQUESTS_METRIC, 2, attributes)
+	backend.Record(context.Background(), hooks.HOOK_STAGE_DURATION_METRIC, 0.5, attributes)
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("error collecting metrics %v", err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+
+	sum, ok := metrics[hooks.EVALUATION_REQUESTS_METRIC].Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 {
+		t.Fatalf("expected a counter with a single data point, got %v", metrics[hooks.EVALUATION_REQUESTS_METRIC].Data)
+	}
+	if sum.DataPoints[0].Value != 3 {
+		t.Errorf("expected a count of 3, got %d", sum.DataPoints[0].Value)
+	}
+	if flag, _ := sum.DataPoints[0].Attributes.Value(attribute.Key(hooks.METRIC_FLAG_KEY)); flag.AsString() != "flag" {
+		t.Errorf("expected the flag key attribute, got %v", sum.DataPoints[0].Attributes)
+	}
+
+	histogram, ok := metrics[hooks.HOOK_STAGE_DURATION_METRIC].Data.(metricdata.Histogram[float64])
+	if !ok || len(histogram.DataPoints) != 1 {
+		t.Fatalf("expected a histogram with a single data point, got %v", metrics[hooks.HOOK_STAGE_DURATION_METRIC].Data)
+	}
+	if histogram.DataPoints[0].Sum != 0.5 {
+		t.Errorf("expected a sum of 0.5, got %f", histogram.DataPoints[0].Sum)
+	}
+	if unit := metrics[hooks.HOOK_STAGE_DURATION_METRIC].Unit; unit != "s" {
+		t.Errorf("expected the unit s, got %s", unit)
+	}
+}
@@ -0,0 +1,123 @@
+// Package otelhook provides hooks reporting flag evaluations to OpenTelemetry.
+package otelhook
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// EventName is the name of the span events recording flag evaluations, following the OpenTelemetry semantic
+// conventions for feature flags
+const EventName = "feature_flag.evaluation"
+
+// Attribute keys of the evaluation events, following the OpenTelemetry semantic conventions for feature flags
+const (
+	FlagKeyKey      = attribute.Key("feature_flag.key")
+	ProviderNameKey = attribute.Key("feature_flag.provider.name")
+	VariantKey      = attribute.Key("feature_flag.result.variant")
+	ReasonKey       = attribute.Key("feature_flag.result.reason")
+	ContextIDKey    = attribute.Key("feature_flag.context.id")
+	ErrorTypeKey    = attribute.Key("error.type")
+	ErrorMessageKey = attribute.Key("error.message")
+)
+
+// TracesHook records each flag evaluation as an event of the span of the evaluation context (ctx), with the flag key,
+// the provider name, the variant, the reason and the error code of failed evaluations. Evaluations made without a
+// recording span in ctx are not recorded.
+type TracesHook struct {
+	openfeature.UnimplementedHook
+	setErrorStatus     bool
+	includeContextID   bool
+	metadataAttributes func(openfeature.FlagMetadata) []attribute.KeyValue
+}
+
+// TracesOption configures a TracesHook
+type TracesOption func(*TracesHook)
+
+// WithErrorStatus sets the status of the span to error when an evaluation fails
+func WithErrorStatus() TracesOption {
+	return func(h *TracesHook) {
+		h.setErrorStatus = true
+	}
+}
+
+// WithContextID records the targeting key of the evaluation context as the context id of the evaluations. The
+// targeting key often identifies a user, so it is not recorded by default.
+func WithContextID() TracesOption {
+	return func(h *TracesHook) {
+		h.includeContextID = true
+	}
+}
+
+// WithMetadataAttributes adds the attributes derived from the flag metadata of successful evaluations to their event,
+// e.g. the owner of the flag
+func WithMetadataAttributes(mapper func(openfeature.FlagMetadata) []attribute.KeyValue) TracesOption {
+	return func(h *TracesHook) {
+		h.metadataAttributes = mapper
+	}
+}
+
+// NewTracesHook returns a hook recording flag evaluations as span events
+func NewTracesHook(options ...TracesOption) *TracesHook {
+	hook := &TracesHook{}
+	for _, option := range options {
+		option(hook)
+	}
+	return hook
+}
+
+// After records a successful evaluation
+func (h *TracesHook) After(ctx context.Context, hookContext openfeature.HookContext, details openfeature.InterfaceEvaluationDetails, _ openfeature.HookHints) error {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+
+	attributes := h.attributes(hookContext)
+	if details.Variant != "" {
+		attributes = append(attributes, VariantKey.String(details.Variant))
+	}
+	if details.Reason != "" {
+		attributes = append(attributes, ReasonKey.String(string(details.Reason)))
+	}
+	if h.metadataAttributes != nil {
+		attributes = append(attributes, h.metadataAttributes(details.FlagMetadata)...)
+	}
+	span.AddEvent(EventName, trace.WithAttributes(attributes...))
+	return nil
+}
+
+// Error records a failed evaluation, with its error code
+func (h *TracesHook) Error(ctx context.Context, hookContext openfeature.HookContext, err error, _ openfeature.HookHints) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attributes := append(h.attributes(hookContext),
+		ReasonKey.String(string(openfeature.ErrorReason)),
+		ErrorTypeKey.String(string(openfeature.ErrorCodeOf(err))),
+		ErrorMessageKey.String(err.Error()),
+	)
+	span.AddEvent(EventName, trace.WithAttributes(attributes...))
+	if h.setErrorStatus {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// attributes returns the attributes shared by the events of successful and failed evaluations
+func (h *TracesHook) attributes(hookContext openfeature.HookContext) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		FlagKeyKey.String(hookContext.FlagKey()),
+		ProviderNameKey.String(hookContext.ProviderMetadata().Name),
+	}
+	if targetingKey := hookContext.EvaluationContext().TargetingKey(); h.includeContextID && targetingKey != "" {
+		attributes = append(attributes, ContextIDKey.String(targetingKey))
+	}
+	return attributes
+}
@@ -0,0 +1,114 @@
+package otelhook
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestTracesHook(t *testing.T) {
+	provider := openfeature.NewFuncProvider(func(ctx context.Context, flag string, flagType openfeature.Type, defaultValue interface{}, evalCtx openfeature.FlattenedContext) (interface{}, openfeature.ProviderResolutionDetail) {
+		if flag == "missing" {
+			return defaultValue, openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewFlagNotFoundResolutionError("missing"),
+				Reason:          openfeature.ErrorReason,
+			}
+		}
+		return true, openfeature.ProviderResolutionDetail{
+			Reason:       openfeature.TargetingMatchReason,
+			Variant:      "on",
+			FlagMetadata: openfeature.FlagMetadata{"owner": "checkout"},
+		}
+	})
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("error setting provider %v", err)
+	}
+	client := openfeature.NewClient(t.Name())
+	client.AddHooks(NewTracesHook(
+		WithErrorStatus(),
+		WithContextID(),
+		WithMetadataAttributes(func(metadata openfeature.FlagMetadata) []attribute.KeyValue {
+			owner, _ := metadata.GetString("owner")
+			return []attribute.KeyValue{attribute.String("feature_flag.owner", owner)}
+		}),
+	))
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(t.Name())
+	ctx, span := tracer.Start(context.Background(), "request")
+	evalCtx := openfeature.NewEvaluationContext("user-1", nil)
+	_, _ = client.BooleanValue(ctx, "flag", false, evalCtx)
+	_, _ = client.BooleanValue(ctx, "missing", false, evalCtx)
+	span.End()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	events := ended[0].Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	tests := map[string]struct {
+		event int
+		want  map[attribute.Key]string
+	}{
+		"success": {
+			event: 0,
+			want: map[attribute.Key]string{
+				FlagKeyKey:           "flag",
+				ProviderNameKey:      "FuncProvider",
+				VariantKey:           "on",
+				ReasonKey:            string(openfeature.TargetingMatchReason),
+				ContextIDKey:         "user-1",
+				"feature_flag.owner": "checkout",
+			},
+		},
+		"error": {
+			event: 1,
+			want: map[attribute.Key]string{
+				FlagKeyKey:   "missing",
+				ReasonKey:    string(openfeature.ErrorReason),
+				ErrorTypeKey: string(openfeature.FlagNotFoundCode),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			event := events[test.event]
+			if event.Name != EventName {
+				t.Errorf("expected event %s, got %s", EventName, event.Name)
+			}
+			attributes := map[attribute.Key]string{}
+			for _, kv := range event.Attributes {
+				attributes[kv.Key] = kv.Value.Emit()
+			}
+			for key, want := range test.want {
+				if attributes[key] != want {
+					t.Errorf("expected attribute %s to be %q, got %q", key, want, attributes[key])
+				}
+			}
+		})
+	}
+
+	if ended[0].Status().Code != codes.Error {
+		t.Errorf("expected the span status to be set to error, got %v", ended[0].Status().Code)
+	}
+}
+
+func TestTracesHookWithoutSpan(t *testing.T) {
+	hook := NewTracesHook()
+	err := hook.After(context.Background(), openfeature.HookContext{}, openfeature.InterfaceEvaluationDetails{}, openfeature.HookHints{})
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	hook.Error(context.Background(), openfeature.HookContext{}, context.Canceled, openfeature.HookHints{})
+}
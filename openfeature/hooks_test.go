@@ -961,3 +961,155 @@ func TestRequirement_4_5_3(t *testing.T) {
 		t.Errorf("expected to retrieve the hint from the underlying map")
 	}
 }
+
+// providerWithHooks is a provider exposing provider level hooks
+type providerWithHooks struct {
+	NoopProvider
+	hooks []Hook
+}
+
+func (p providerWithHooks) Hooks() []Hook {
+	return p.hooks
+}
+
+type apiTraceHook struct{ UnimplementedHook }
+type clientTraceHook struct{ UnimplementedHook }
+type invocationTraceHook struct{ UnimplementedHook }
+type providerTraceHook struct{ UnimplementedHook }
+
+func TestWithHookTracing(t *testing.T) {
+	executor := newEventExecutor()
+	evalAPI := newEvaluationAPI(executor)
+	err := evalAPI.SetProviderAndWait(providerWithHooks{hooks: []Hook{providerTraceHook{}}})
+	if err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	evalAPI.AddHooks(apiTraceHook{})
+
+	client := newClient(t.Name(), evalAPI, executor)
+	client.AddHooks(clientTraceHook{})
+
+	var events []HookTraceEvent
+	tracer := func(event HookTraceEvent) {
+		events = append(events, event)
+	}
+
+	_, err = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{},
+		WithHooks(invocationTraceHook{}), WithHookTracing(tracer))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type trace struct {
+		stage    HookStage
+		scope    HookScope
+		hookType string
+	}
+
+	expected := []trace{
+		{BeforeHookStage, APIHookScope, "openfeature.apiTraceHook"},
+		{BeforeHookStage, ClientHookScope, "openfeature.clientTraceHook"},
+		{BeforeHookStage, InvocationHookScope, "openfeature.invocationTraceHook"},
+		{BeforeHookStage, ProviderHookScope, "openfeature.providerTraceHook"},
+		{AfterHookStage, ProviderHookScope, "openfeature.providerTraceHook"},
+		{AfterHookStage, InvocationHookScope, "openfeature.invocationTraceHook"},
+		{AfterHookStage, ClientHookScope, "openfeature.clientTraceHook"},
+		{AfterHookStage, APIHookScope, "openfeature.apiTraceHook"},
+		{FinallyHookStage, ProviderHookScope, "openfeature.providerTraceHook"},
+		{FinallyHookStage, InvocationHookScope, "openfeature.invocationTraceHook"},
+		{FinallyHookStage, ClientHookScope, "openfeature.clientTraceHook"},
+		{FinallyHookStage, APIHookScope, "openfeature.apiTraceHook"},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d trace events, got %d: %v", len(expected), len(events), events)
+	}
+
+	for i, event := range events {
+		got := trace{event.Stage, event.Scope, event.HookType}
+		if got != expected[i] {
+			t.Errorf("unexpected trace event at position %d, expected %v, got %v", i, expected[i], got)
+		}
+		if event.FlagKey != "flag" {
+			t.Errorf("expected trace event for flag %q, got %q", "flag", event.FlagKey)
+		}
+	}
+}
+
+// countingHook counts its before stage executions
+type countingHook struct {
+	UnimplementedHook
+	count *int
+}
+
+func (h countingHook) Before(context.Context, HookContext, HookHints) (*EvaluationContext, error) {
+	*h.count++
+	return nil, nil
+}
+
+func TestClientAddHooksForType(t *testing.T) {
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	var objectHookCount, clientHookCount int
+	client.AddHooks(countingHook{count: &clientHookCount})
+	client.AddHooksForType(Object, countingHook{count: &objectHookCount})
+
+	_, _ = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+	_, _ = client.ObjectValue(context.Background(), "flag", nil, EvaluationContext{})
+
+	if clientHookCount != 2 {
+		t.Errorf("expected client hook to run for every evaluation, ran %d times", clientHookCount)
+	}
+	if objectHookCount != 1 {
+		t.Errorf("expected object hook to run only for the object evaluation, ran %d times", objectHookCount)
+	}
+}
+
+// hookedProvider is a NoopProvider with provider hooks
+type hookedProvider struct {
+	NoopProvider
+	hooks []Hook
+}
+
+func (p hookedProvider) Hooks() []Hook {
+	return p.hooks
+}
+
+func TestClientEffectiveHooks(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	client := newClient(t.Name(), api, executor)
+
+	var count int
+	apiHook := countingHook{count: &count}
+	clientHook := UnimplementedHook{}
+	objectHook := countingHook{count: new(int)}
+	providerHook := countingHook{count: new(int)}
+	if err := api.SetProviderAndWait(hookedProvider{hooks: []Hook{providerHook}}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	api.AddHooks(apiHook)
+	client.AddHooks(clientHook)
+	client.AddHooksForType(Object, objectHook)
+
+	hooks := client.EffectiveHooks()
+	want := EffectiveHooks{
+		API:          []Hook{apiHook},
+		Client:       []Hook{clientHook},
+		ClientByType: map[Type][]Hook{Object: {objectHook}},
+		Provider:     []Hook{providerHook},
+	}
+	if !reflect.DeepEqual(hooks, want) {
+		t.Errorf("expected hooks %+v, got %+v", want, hooks)
+	}
+
+	// the returned hooks are copies
+	hooks.Client[0] = apiHook
+	if client.EffectiveHooks().Client[0] != clientHook {
+		t.Error("expected modifying the effective hooks not to affect the client")
+	}
+}
@@ -0,0 +1,362 @@
+package httpprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+)
+
+// DefaultPollingInterval is the polling interval of providers created with a zero or negative interval
+const DefaultPollingInterval = 30 * time.Second
+
+// HTTPPollingProvider is an OpenFeature compliant provider serving flags from a JSON document fetched periodically
+// from a remote HTTP endpoint. The document maps flag keys to flags in the memprovider.InMemoryFlag schema, e.g.
+//
+//	{
+//	  "new-welcome-message": {
+//	    "state": "ENABLED",
+//	    "defaultVariant": "on",
+//	    "variants": {"on": true, "off": false}
+//	  }
+//	}
+//
+// Flags are resolved with the semantics of memprovider.InMemoryProvider.
+type HTTPPollingProvider struct {
+	url      string
+	interval time.Duration
+	client   *http.Client
+
+	mu       sync.RWMutex
+	flags    map[string]memprovider.InMemoryFlag
+	resolver memprovider.InMemoryProvider
+	etag     string
+	failing  bool
+	fetched  time.Time
+
+	events       chan openfeature.Event
+	done         chan struct{}
+	startOnce    sync.Once
+	shutdownOnce sync.Once
+}
+
+// Option applies a change to an HTTPPollingProvider
+type Option func(*HTTPPollingProvider)
+
+// WithHTTPClient sets the HTTP client used to fetch the flags document. Defaults to a client with a 10 seconds timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *HTTPPollingProvider) {
+		p.client = client
+	}
+}
+
+// NewHTTPPollingProvider returns a provider fetching its flags from url every interval. A zero or negative interval
+// is replaced with DefaultPollingInterval.
+//
+// The provider fetches the flags document at initialization. Until a fetch succeeds, it is not ready: initialization
+// retries the fetch every interval. SetProviderAndWait thus blocks until the first successful fetch, use
+// SetProviderAndWaitContext to bound the wait; initialization then fails with the context, polling goes on in the
+// background and a later successful fetch emits PROVIDER_READY.
+//
+// Unchanged documents are not downloaded again, by sending the ETag of the last document as If-None-Match.
+// A changed document replaces the flags and emits PROVIDER_CONFIGURATION_CHANGED, listing the changed flags.
+// A failed fetch keeps the last fetched flags and emits PROVIDER_ERROR.
+func NewHTTPPollingProvider(url string, interval time.Duration, opts ...Option) *HTTPPollingProvider {
+	provider := &HTTPPollingProvider{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		flags:    map[string]memprovider.InMemoryFlag{},
+		resolver: memprovider.NewInMemoryProvider(map[string]memprovider.InMemoryFlag{}),
+		events:   make(chan openfeature.Event, 5),
+		done:     make(chan struct{}),
+	}
+
+	if provider.interval <= 0 {
+		provider.interval = DefaultPollingInterval
+	}
+
+	for _, opt := range opts {
+		opt(provider)
+	}
+
+	return provider
+}
+
+// Metadata returns the metadata of the provider
+func (p *HTTPPollingProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: "HTTPPollingProvider"}
+}
+
+// Hooks returns the hooks of the provider
+func (p *HTTPPollingProvider) Hooks() []openfeature.Hook {
+	return []openfeature.Hook{}
+}
+
+// Init fetches the flags document and starts polling. Until a fetch succeeds, Init retries every interval, so that the
+// provider stays NOT_READY, and returns an error only if the provider is shut down meanwhile.
+func (p *HTTPPollingProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	return p.InitWithContext(context.Background(), evaluationContext)
+}
+
+// InitWithContext is Init, with the retries of the first fetch bound to ctx. If ctx is done before a fetch succeeds,
+// an error is returned and polling goes on in the background, emitting PROVIDER_READY on the first successful fetch.
+func (p *HTTPPollingProvider) InitWithContext(ctx context.Context, _ openfeature.EvaluationContext) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := p.fetch(ctx)
+		if err == nil {
+			p.startOnce.Do(func() {
+				go p.poll()
+			})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.failing = true
+			p.mu.Unlock()
+			p.startOnce.Do(func() {
+				go p.poll()
+			})
+			return fmt.Errorf("initial fetch of %s: %w", p.url, err)
+		case <-p.done:
+			return fmt.Errorf("initial fetch of %s: provider shut down: %w", p.url, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops polling
+func (p *HTTPPollingProvider) Shutdown() {
+	p.shutdownOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// EventChannel returns the channel of the provider events
+func (p *HTTPPollingProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// BooleanEvaluation resolves a boolean flag from the last fetched flags
+func (p *HTTPPollingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	return p.current().BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// StringEvaluation resolves a string flag from the last fetched flags
+func (p *HTTPPollingProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	return p.current().StringEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// FloatEvaluation resolves a float flag from the last fetched flags
+func (p *HTTPPollingProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	return p.current().FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// IntEvaluation resolves an int flag from the last fetched flags.
+// JSON numbers decode as float64, so the flag resolves as a float, which must be a whole number.
+func (p *HTTPPollingProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	res := p.current().FloatEvaluation(ctx, flag, float64(defaultValue), evalCtx)
+	if res.Error() != nil {
+		return openfeature.IntResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: res.ProviderResolutionDetail,
+		}
+	}
+
+	if res.Value != math.Trunc(res.Value) {
+		return openfeature.IntResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewTypeMismatchResolutionError("incorrect type association"),
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+
+	return openfeature.IntResolutionDetail{
+		Value:                    int64(res.Value),
+		ProviderResolutionDetail: res.ProviderResolutionDetail,
+	}
+}
+
+// ObjectEvaluation resolves an object flag from the last fetched flags
+func (p *HTTPPollingProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return p.current().ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// LastUpdated returns the time of the last successful fetch of the flags document, so that the age of the served flags
+// is reported while fetches fail. See openfeature.FreshnessReporter.
+func (p *HTTPPollingProvider) LastUpdated() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.fetched
+}
+
+func (p *HTTPPollingProvider) current() memprovider.InMemoryProvider {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.resolver
+}
+
+// poll fetches the flags document every interval until shutdown
+func (p *HTTPPollingProvider) poll() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.refresh()
+		}
+	}
+}
+
+// refresh fetches the flags document and emits the resulting event, if any
+func (p *HTTPPollingProvider) refresh() {
+	changed, err := p.fetch(context.Background())
+
+	p.mu.Lock()
+	wasFailing := p.failing
+	p.failing = err != nil
+	p.mu.Unlock()
+
+	switch {
+	case err != nil:
+		p.emit(openfeature.Event{
+			EventType: openfeature.ProviderError,
+			ProviderEventDetails: openfeature.ProviderEventDetails{
+				Message:   fmt.Sprintf("fetch of %s failed, serving last fetched flags: %v", p.url, err),
+				ErrorCode: openfeature.GeneralCode,
+			},
+		})
+	case wasFailing:
+		p.emit(openfeature.Event{
+			EventType: openfeature.ProviderReady,
+			ProviderEventDetails: openfeature.ProviderEventDetails{
+				Message:           fmt.Sprintf("fetched flags from %s", p.url),
+				FlagChanges:       flagKeys(changed),
+				FlagChangeDetails: changed,
+			},
+		})
+	case len(changed) > 0:
+		p.emit(openfeature.Event{
+			EventType: openfeature.ProviderConfigChange,
+			ProviderEventDetails: openfeature.ProviderEventDetails{
+				Message:           fmt.Sprintf("flags changed at %s", p.url),
+				FlagChanges:       flagKeys(changed),
+				FlagChangeDetails: changed,
+			},
+		})
+	}
+}
+
+// fetch downloads the flags document, if changed since the last fetch, and replaces the flags.
+// Returns the changes of the flags.
+func (p *HTTPPollingProvider) fetch(ctx context.Context) (map[string]openfeature.FlagChange, error) {
+	p.mu.RLock()
+	etag := p.etag
+	p.mu.RUnlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotModified {
+		p.mu.Lock()
+		p.fetched = time.Now()
+		p.mu.Unlock()
+		return nil, nil
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", rsp.Status)
+	}
+
+	var flags map[string]memprovider.InMemoryFlag
+	if err := json.NewDecoder(rsp.Body).Decode(&flags); err != nil {
+		return nil, fmt.Errorf("decoding flags: %w", err)
+	}
+	for key, flag := range flags {
+		if flag.Key == "" {
+			flag.Key = key
+			flags[key] = flag
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	changed := changedFlags(p.flags, flags)
+	p.flags = flags
+	p.resolver = memprovider.NewInMemoryProvider(flags)
+	p.etag = rsp.Header.Get("ETag")
+	p.fetched = time.Now()
+
+	return changed, nil
+}
+
+// emit sends the event, unless the provider is shut down
+func (p *HTTPPollingProvider) emit(event openfeature.Event) {
+	select {
+	case p.events <- event:
+	case <-p.done:
+	}
+}
+
+// changedFlags describes the flags added, removed or modified between old and updated, keyed by flag key
+func changedFlags(old, updated map[string]memprovider.InMemoryFlag) map[string]openfeature.FlagChange {
+	changes := map[string]openfeature.FlagChange{}
+	for key, flag := range updated {
+		oldFlag, ok := old[key]
+		switch {
+		case !ok:
+			changes[key] = openfeature.FlagChange{Type: openfeature.FlagAdded, NewVariant: flag.DefaultVariant}
+		case !reflect.DeepEqual(oldFlag, flag):
+			changes[key] = openfeature.FlagChange{
+				Type:       openfeature.FlagModified,
+				OldVariant: oldFlag.DefaultVariant,
+				NewVariant: flag.DefaultVariant,
+			}
+		}
+	}
+	for key, flag := range old {
+		if _, ok := updated[key]; !ok {
+			changes[key] = openfeature.FlagChange{Type: openfeature.FlagRemoved, OldVariant: flag.DefaultVariant}
+		}
+	}
+	return changes
+}
+
+// flagKeys returns the sorted keys of the flag changes
+func flagKeys(changes map[string]openfeature.FlagChange) []string {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,253 @@
+package httpprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// flagServer serves a flags document with a version based ETag
+type flagServer struct {
+	mu           sync.Mutex
+	document     string
+	version      int
+	failing      bool
+	notModifieds int
+}
+
+func (s *flagServer) set(document string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.document = document
+	s.version++
+}
+
+func (s *flagServer) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *flagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%d"`, s.version)
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModifieds++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write([]byte(s.document))
+}
+
+const document = `{
+	"bool-flag": {"state": "ENABLED", "defaultVariant": "on", "variants": {"on": true, "off": false}},
+	"int-flag": {"state": "ENABLED", "defaultVariant": "ten", "variants": {"ten": 10, "half": 0.5}}
+}`
+
+func receive(t *testing.T, events <-chan openfeature.Event) openfeature.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+		return openfeature.Event{}
+	}
+}
+
+func TestHTTPPollingProvider(t *testing.T) {
+	server := &flagServer{}
+	server.set(document)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	provider := NewHTTPPollingProvider(httpServer.URL, 10*time.Millisecond)
+	defer provider.Shutdown()
+
+	if err := provider.Init(openfeature.EvaluationContext{}); err != nil {
+		t.Fatalf("unexpected init error: %v", err)
+	}
+
+	ctx := context.Background()
+	if res := provider.BooleanEvaluation(ctx, "bool-flag", false, nil); !res.Value || res.Variant != "on" {
+		t.Errorf("unexpected boolean resolution %+v", res)
+	}
+	if res := provider.IntEvaluation(ctx, "int-flag", 0, nil); res.Value != 10 || res.Error() != nil {
+		t.Errorf("unexpected int resolution %+v", res)
+	}
+	if lastUpdated := provider.LastUpdated(); lastUpdated.IsZero() || time.Since(lastUpdated) > time.Second {
+		t.Errorf("expected the fetch time to be reported, got %s", lastUpdated)
+	}
+
+	t.Run("unchanged document is not downloaded again", func(t *testing.T) {
+		deadline := time.Now().Add(time.Second)
+		for {
+			server.mu.Lock()
+			notModifieds := server.notModifieds
+			server.mu.Unlock()
+			if notModifieds > 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected conditional requests")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("changed document emits a configuration change", func(t *testing.T) {
+		server.set(`{
+			"bool-flag": {"state": "ENABLED", "defaultVariant": "off", "variants": {"on": true, "off": false}},
+			"string-flag": {"state": "ENABLED", "defaultVariant": "a", "variants": {"a": "a"}}
+		}`)
+
+		event := receive(t, provider.EventChannel())
+		if event.EventType != openfeature.ProviderConfigChange {
+			t.Fatalf("expected %s, got %s", openfeature.ProviderConfigChange, event.EventType)
+		}
+		if expected := []string{"bool-flag", "int-flag", "string-flag"}; !reflect.DeepEqual(expected, event.FlagChanges) {
+			t.Errorf("expected flag changes %v, got %v", expected, event.FlagChanges)
+		}
+		expected := map[string]openfeature.FlagChange{
+			"bool-flag":   {Type: openfeature.FlagModified, OldVariant: "on", NewVariant: "off"},
+			"int-flag":    {Type: openfeature.FlagRemoved, OldVariant: "ten"},
+			"string-flag": {Type: openfeature.FlagAdded, NewVariant: "a"},
+		}
+		if !reflect.DeepEqual(expected, event.FlagChangeDetails) {
+			t.Errorf("expected flag change details %v, got %v", expected, event.FlagChangeDetails)
+		}
+
+		if res := provider.BooleanEvaluation(ctx, "bool-flag", true, nil); res.Value {
+			t.Errorf("expected the changed flag to be served, got %+v", res)
+		}
+	})
+
+	t.Run("failed fetch keeps the last flags and emits an error", func(t *testing.T) {
+		server.setFailing(true)
+
+		event := receive(t, provider.EventChannel())
+		if event.EventType != openfeature.ProviderError {
+			t.Fatalf("expected %s, got %s", openfeature.ProviderError, event.EventType)
+		}
+		if res := provider.StringEvaluation(ctx, "string-flag", "", nil); res.Value != "a" {
+			t.Errorf("expected the last fetched flags to be served, got %+v", res)
+		}
+
+		server.setFailing(false)
+		for event.EventType == openfeature.ProviderError {
+			event = receive(t, provider.EventChannel())
+		}
+		if event.EventType != openfeature.ProviderReady {
+			t.Errorf("expected %s after recovery, got %s", openfeature.ProviderReady, event.EventType)
+		}
+	})
+}
+
+func TestHTTPPollingProviderInitFailure(t *testing.T) {
+	t.Run("not ready until a fetch succeeds", func(t *testing.T) {
+		server := &flagServer{failing: true}
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		provider := NewHTTPPollingProvider(httpServer.URL, 10*time.Millisecond)
+		defer provider.Shutdown()
+
+		domain := t.Name()
+		if err := openfeature.SetNamedProvider(domain, provider); err != nil {
+			t.Fatalf("failed to set up provider: %v", err)
+		}
+		client := openfeature.NewClient(domain)
+
+		time.Sleep(50 * time.Millisecond)
+		if state := client.State(); state != openfeature.NotReadyState {
+			t.Fatalf("expected %s while fetches fail, got %s", openfeature.NotReadyState, state)
+		}
+
+		server.set(document)
+		server.setFailing(false)
+
+		deadline := time.Now().Add(time.Second)
+		for client.State() != openfeature.ReadyState {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the provider to get ready, got %s", client.State())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if value, _ := client.BooleanValue(context.Background(), "bool-flag", false, openfeature.EvaluationContext{}); !value {
+			t.Error("expected the fetched flag to be served")
+		}
+	})
+
+	t.Run("bounded initialization", func(t *testing.T) {
+		server := &flagServer{failing: true}
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		provider := NewHTTPPollingProvider(httpServer.URL, 10*time.Millisecond)
+		defer provider.Shutdown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := provider.InitWithContext(ctx, openfeature.EvaluationContext{}); err == nil {
+			t.Fatal("expected an init error when no fetch succeeds before the context is done")
+		}
+
+		server.set(document)
+		server.setFailing(false)
+
+		event := receive(t, provider.EventChannel())
+		if event.EventType != openfeature.ProviderReady {
+			t.Fatalf("expected %s, got %s", openfeature.ProviderReady, event.EventType)
+		}
+		if res := provider.BooleanEvaluation(context.Background(), "bool-flag", false, nil); !res.Value {
+			t.Errorf("unexpected boolean resolution %+v", res)
+		}
+	})
+
+	t.Run("shutdown during initialization", func(t *testing.T) {
+		server := &flagServer{failing: true}
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		provider := NewHTTPPollingProvider(httpServer.URL, 10*time.Millisecond)
+		errs := make(chan error, 1)
+		go func() {
+			errs <- provider.Init(openfeature.EvaluationContext{})
+		}()
+
+		provider.Shutdown()
+		select {
+		case err := <-errs:
+			if err == nil {
+				t.Error("expected an init error when shut down before a fetch succeeds")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected Init to return on shutdown")
+		}
+	})
+}
+
+func TestHTTPPollingProviderNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		provider := NewHTTPPollingProvider("http://localhost", interval)
+		if provider.interval != DefaultPollingInterval {
+			t.Errorf("expected interval %s to be replaced with %s, got %s", interval, DefaultPollingInterval, provider.interval)
+		}
+	}
+}
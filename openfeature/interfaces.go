@@ -1,19 +1,40 @@
 package openfeature
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // IEvaluation defines the OpenFeature API contract
 type IEvaluation interface {
 	SetProvider(provider FeatureProvider) error
 	SetProviderAndWait(provider FeatureProvider) error
+	SetProviderOnce(provider FeatureProvider) error
+	ForceSetProvider(provider FeatureProvider) error
+	SetProviderAndWaitForStates(provider FeatureProvider, states ...State) error
+	SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error
+	SetProviderWithMetadata(provider FeatureProvider, metadata map[string]interface{}) error
+	SetProviderWithShutdownTimeout(provider FeatureProvider, timeout time.Duration) error
+	SetProviderWithOptions(provider FeatureProvider, options ...RegistrationOption) error
 	GetProviderMetadata() Metadata
 	SetNamedProvider(clientName string, provider FeatureProvider, async bool) error
+	SetNamedProviderAndWaitContext(ctx context.Context, clientName string, provider FeatureProvider) error
+	SetNamedProviderWithOptions(clientName string, provider FeatureProvider, options ...RegistrationOption) error
+	MarkReady(domain string) error
+	ProviderStats(domain string) Stats
+	WaitUntilReady(ctx context.Context, domains ...string) error
+	SubscribeEvaluations(callback func(EvaluationEvent)) (unsubscribe func())
 	GetNamedProviderMetadata(name string) Metadata
 	GetClient() IClient
 	GetNamedClient(clientName string) IClient
 	SetEvaluationContext(apiCtx EvaluationContext)
+	SetContextMergePrecedence(precedence []ContextLevel) error
 	AddHooks(hooks ...Hook)
+	AllClientsState() State
 	Shutdown()
+	SetEventHistory(size int)
+	AddHandlerWithHistory(eventType EventType, callback EventCallback)
+	ExplainHandlerRouting(domain string, eventType EventType) string
 	IEventing
 }
 
@@ -8,3 +8,24 @@ type ContextKey struct{}
 // TransactionContext is the context key to use with golang.org/x/net/context's
 // WithValue function to associate an EvaluationContext value with a context.
 var TransactionContext ContextKey
+
+// CorrelationIDKey is the type of the CorrelationID context key. It is distinct from ContextKey, so that the
+// CorrelationID and TransactionContext keys do not collide.
+type CorrelationIDKey struct{}
+
+// CorrelationID is the context key to use with context.WithValue to associate a correlation id string with a context.
+var CorrelationID CorrelationIDKey
+
+// ProviderOptionsKey is the type of the ProviderOptions context key
+type ProviderOptionsKey struct{}
+
+// ProviderOptions is the context key to use with context.WithValue to associate the provider options of an
+// evaluation with a context.
+var ProviderOptions ProviderOptionsKey
+
+// SerializedDefaultKey is the type of the SerializedDefault context key
+type SerializedDefaultKey struct{}
+
+// SerializedDefault is the context key to use with context.WithValue to associate the map form of the default value
+// of an object evaluation with a context.
+var SerializedDefault SerializedDefaultKey
@@ -3,6 +3,7 @@ package memprovider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/open-feature/go-sdk/openfeature"
 )
@@ -15,12 +16,66 @@ const (
 type InMemoryProvider struct {
 	flags          map[string]InMemoryFlag
 	trackingEvents map[string][]InMemoryEvent
+	simulation     simulation
 }
 
-func NewInMemoryProvider(from map[string]InMemoryFlag) InMemoryProvider {
-	return InMemoryProvider{
+// Option applies a change to an InMemoryProvider
+type Option func(*InMemoryProvider)
+
+// simulation holds the artificial latency and errors injected into evaluations
+type simulation struct {
+	latency     time.Duration
+	flagLatency map[string]time.Duration
+	err         *openfeature.ResolutionError
+	flagErrors  map[string]openfeature.ResolutionError
+}
+
+func NewInMemoryProvider(from map[string]InMemoryFlag, opts ...Option) InMemoryProvider {
+	provider := InMemoryProvider{
 		flags:          from,
 		trackingEvents: map[string][]InMemoryEvent{},
+		simulation: simulation{
+			flagLatency: map[string]time.Duration{},
+			flagErrors:  map[string]openfeature.ResolutionError{},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&provider)
+	}
+
+	return provider
+}
+
+// WithSimulatedLatency delays every evaluation by the given duration.
+// The delay is cut short if the evaluation's context is done, in which case the default value is returned with an
+// error.
+func WithSimulatedLatency(d time.Duration) Option {
+	return func(p *InMemoryProvider) {
+		p.simulation.latency = d
+	}
+}
+
+// WithSimulatedFlagLatency delays evaluations of the given flag by the given duration.
+// It takes precedence over WithSimulatedLatency for this flag.
+func WithSimulatedFlagLatency(flagKey string, d time.Duration) Option {
+	return func(p *InMemoryProvider) {
+		p.simulation.flagLatency[flagKey] = d
+	}
+}
+
+// WithSimulatedError makes evaluations of the given flag return the default value along with the given error.
+func WithSimulatedError(flagKey string, err openfeature.ResolutionError) Option {
+	return func(p *InMemoryProvider) {
+		p.simulation.flagErrors[flagKey] = err
+	}
+}
+
+// WithSimulatedGlobalError makes evaluations of every flag return the default value along with the given error.
+// Errors configured for a specific flag with WithSimulatedError take precedence.
+func WithSimulatedGlobalError(err openfeature.ResolutionError) Option {
+	return func(p *InMemoryProvider) {
+		p.simulation.err = &err
 	}
 }
 
@@ -31,6 +86,13 @@ func (i InMemoryProvider) Metadata() openfeature.Metadata {
 }
 
 func (i InMemoryProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	if details, ok := i.simulate(ctx, flag); !ok {
+		return openfeature.BoolResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
 	memoryFlag, details, ok := i.find(flag)
 	if !ok {
 		return openfeature.BoolResolutionDetail{
@@ -49,6 +111,13 @@ func (i InMemoryProvider) BooleanEvaluation(ctx context.Context, flag string, de
 }
 
 func (i InMemoryProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	if details, ok := i.simulate(ctx, flag); !ok {
+		return openfeature.StringResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
 	memoryFlag, details, ok := i.find(flag)
 	if !ok {
 		return openfeature.StringResolutionDetail{
@@ -67,6 +136,13 @@ func (i InMemoryProvider) StringEvaluation(ctx context.Context, flag string, def
 }
 
 func (i InMemoryProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	if details, ok := i.simulate(ctx, flag); !ok {
+		return openfeature.FloatResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
 	memoryFlag, details, ok := i.find(flag)
 	if !ok {
 		return openfeature.FloatResolutionDetail{
@@ -85,6 +161,13 @@ func (i InMemoryProvider) FloatEvaluation(ctx context.Context, flag string, defa
 }
 
 func (i InMemoryProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	if details, ok := i.simulate(ctx, flag); !ok {
+		return openfeature.IntResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
 	memoryFlag, details, ok := i.find(flag)
 	if !ok {
 		return openfeature.IntResolutionDetail{
@@ -103,6 +186,13 @@ func (i InMemoryProvider) IntEvaluation(ctx context.Context, flag string, defaul
 }
 
 func (i InMemoryProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	if details, ok := i.simulate(ctx, flag); !ok {
+		return openfeature.InterfaceResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: *details,
+		}
+	}
+
 	memoryFlag, details, ok := i.find(flag)
 	if !ok {
 		return openfeature.InterfaceResolutionDetail{
@@ -114,7 +204,7 @@ func (i InMemoryProvider) ObjectEvaluation(ctx context.Context, flag string, def
 	resolveFlag, detail := memoryFlag.Resolve(defaultValue, evalCtx)
 
 	var result interface{}
-	if resolveFlag != nil {
+	if resolveFlag != nil || detail.Reason == openfeature.DisabledReason {
 		result = resolveFlag
 	} else {
 		result = defaultValue
@@ -132,6 +222,18 @@ func (i InMemoryProvider) Hooks() []openfeature.Hook {
 	return []openfeature.Hook{}
 }
 
+// FlagSchema returns the type of each flag, derived from the type of its variant values. Flags without variants or
+// with variants of different types are omitted.
+func (i InMemoryProvider) FlagSchema() map[string]openfeature.Type {
+	schema := make(map[string]openfeature.Type, len(i.flags))
+	for key, flag := range i.flags {
+		if flagType, ok := flag.variantType(); ok {
+			schema[key] = flagType
+		}
+	}
+	return schema
+}
+
 func (i InMemoryProvider) Track(ctx context.Context, trackingEventName string, evalCtx openfeature.EvaluationContext, details openfeature.TrackingEventDetails) {
 	i.trackingEvents[trackingEventName] = append(i.trackingEvents[trackingEventName], InMemoryEvent{
 		Value:             details.Value(),
@@ -140,6 +242,13 @@ func (i InMemoryProvider) Track(ctx context.Context, trackingEventName string, e
 	})
 }
 
+// Reset discards the tracking events recorded by the provider
+func (i InMemoryProvider) Reset() {
+	for name := range i.trackingEvents {
+		delete(i.trackingEvents, name)
+	}
+}
+
 func (i InMemoryProvider) find(flag string) (*InMemoryFlag, *openfeature.ProviderResolutionDetail, bool) {
 	memoryFlag, ok := i.flags[flag]
 	if !ok {
@@ -153,6 +262,45 @@ func (i InMemoryProvider) find(flag string) (*InMemoryFlag, *openfeature.Provide
 	return &memoryFlag, nil, true
 }
 
+// simulate applies the configured latency and errors for the given flag.
+// Returns false along with the resolution detail to use if the evaluation must not proceed.
+func (i InMemoryProvider) simulate(ctx context.Context, flag string) (*openfeature.ProviderResolutionDetail, bool) {
+	latency, ok := i.simulation.flagLatency[flag]
+	if !ok {
+		latency = i.simulation.latency
+	}
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return &openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewGeneralResolutionError(ctx.Err().Error()),
+				Reason:          openfeature.ErrorReason,
+			}, false
+		}
+	}
+
+	if err, ok := i.simulation.flagErrors[flag]; ok {
+		return &openfeature.ProviderResolutionDetail{
+			ResolutionError: err,
+			Reason:          openfeature.ErrorReason,
+		}, false
+	}
+
+	if i.simulation.err != nil {
+		return &openfeature.ProviderResolutionDetail{
+			ResolutionError: *i.simulation.err,
+			Reason:          openfeature.ErrorReason,
+		}, false
+	}
+
+	return nil, true
+}
+
 // helpers
 
 // genericResolve is a helper to extract type verified evaluation and fill openfeature.ProviderResolutionDetail
@@ -192,8 +340,7 @@ func (flag *InMemoryFlag) Resolve(defaultValue interface{}, evalCtx openfeature.
 	// check the state
 	if flag.State == Disabled {
 		return defaultValue, openfeature.ProviderResolutionDetail{
-			ResolutionError: openfeature.NewGeneralResolutionError("flag is disabled"),
-			Reason:          openfeature.DisabledReason,
+			Reason: openfeature.DisabledReason,
 		}
 	}
 
@@ -210,6 +357,37 @@ func (flag *InMemoryFlag) Resolve(defaultValue interface{}, evalCtx openfeature.
 	}
 }
 
+// variantType returns the flag type matching the values of all variants of the flag
+func (flag *InMemoryFlag) variantType() (openfeature.Type, bool) {
+	var flagType openfeature.Type
+	found := false
+	for _, value := range flag.Variants {
+		valueType := typeOf(value)
+		if found && valueType != flagType {
+			return 0, false
+		}
+		flagType = valueType
+		found = true
+	}
+	return flagType, found
+}
+
+// typeOf returns the flag type of a variant value
+func typeOf(value interface{}) openfeature.Type {
+	switch value.(type) {
+	case bool:
+		return openfeature.Boolean
+	case string:
+		return openfeature.String
+	case float64:
+		return openfeature.Float
+	case int, int64:
+		return openfeature.Int
+	default:
+		return openfeature.Object
+	}
+}
+
 type InMemoryEvent struct {
 	Value             float64
 	Data              map[string]interface{}
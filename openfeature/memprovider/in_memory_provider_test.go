@@ -2,7 +2,9 @@ package memprovider
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/open-feature/go-sdk/openfeature"
 )
@@ -244,6 +246,10 @@ func TestInMemoryProvider_Disabled(t *testing.T) {
 		if evaluation.Reason != openfeature.DisabledReason {
 			t.Errorf("incorect reason, expected %v, got %v", openfeature.ErrorReason, evaluation.Reason)
 		}
+
+		if evaluation.Error() != nil {
+			t.Errorf("expected no error for a disabled flag, got %v", evaluation.Error())
+		}
 	})
 }
 
@@ -265,3 +271,178 @@ func TestInMemoryProvider_Track(t *testing.T) {
 	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{})
 	memoryProvider.Track(context.Background(), "example-event-name", openfeature.EvaluationContext{}, openfeature.TrackingEventDetails{})
 }
+
+func TestInMemoryProvider_Reset(t *testing.T) {
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{})
+	memoryProvider.Track(context.Background(), "example-event-name", openfeature.EvaluationContext{}, openfeature.NewTrackingEventDetails(1))
+
+	var resettable openfeature.Resettable = memoryProvider
+	resettable.Reset()
+
+	if len(memoryProvider.trackingEvents) != 0 {
+		t.Errorf("expected tracking events to be discarded, got %v", memoryProvider.trackingEvents)
+	}
+}
+
+func TestInMemoryProvider_SimulatedLatency(t *testing.T) {
+	flags := map[string]InMemoryFlag{
+		"boolFlag": {
+			Key:            "boolFlag",
+			State:          Enabled,
+			DefaultVariant: "true",
+			Variants: map[string]interface{}{
+				"true":  true,
+				"false": false,
+			},
+		},
+	}
+
+	t.Run("evaluation is delayed", func(t *testing.T) {
+		memoryProvider := NewInMemoryProvider(flags, WithSimulatedLatency(50*time.Millisecond))
+
+		start := time.Now()
+		evaluation := memoryProvider.BooleanEvaluation(context.Background(), "boolFlag", false, nil)
+
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("expected evaluation to take at least %v, took %v", 50*time.Millisecond, elapsed)
+		}
+
+		if evaluation.Value != true {
+			t.Errorf("incorect evaluation, expected %t, got %t", true, evaluation.Value)
+		}
+	})
+
+	t.Run("flag latency takes precedence", func(t *testing.T) {
+		memoryProvider := NewInMemoryProvider(flags,
+			WithSimulatedLatency(time.Hour),
+			WithSimulatedFlagLatency("boolFlag", time.Millisecond),
+		)
+
+		evaluation := memoryProvider.BooleanEvaluation(context.Background(), "boolFlag", false, nil)
+
+		if evaluation.Value != true {
+			t.Errorf("incorect evaluation, expected %t, got %t", true, evaluation.Value)
+		}
+	})
+
+	t.Run("canceled context returns default with error", func(t *testing.T) {
+		memoryProvider := NewInMemoryProvider(flags, WithSimulatedLatency(time.Hour))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		evaluation := memoryProvider.BooleanEvaluation(ctx, "boolFlag", false, nil)
+
+		if evaluation.Value != false {
+			t.Errorf("incorect evaluation, expected %t, got %t", false, evaluation.Value)
+		}
+
+		if evaluation.ResolutionDetail().ErrorCode != openfeature.GeneralCode {
+			t.Errorf("incorect error code, expected %v, got %v", openfeature.GeneralCode, evaluation.ResolutionDetail().ErrorCode)
+		}
+	})
+}
+
+func TestInMemoryProvider_SimulatedError(t *testing.T) {
+	flags := map[string]InMemoryFlag{
+		"stringFlag": {
+			Key:            "stringFlag",
+			State:          Enabled,
+			DefaultVariant: "stringOne",
+			Variants: map[string]interface{}{
+				"stringOne": "hello",
+			},
+		},
+		"intFlag": {
+			Key:            "intFlag",
+			State:          Enabled,
+			DefaultVariant: "one",
+			Variants: map[string]interface{}{
+				"one": 1,
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	t.Run("flag error", func(t *testing.T) {
+		memoryProvider := NewInMemoryProvider(flags,
+			WithSimulatedError("stringFlag", openfeature.NewParseErrorResolutionError("simulated")),
+		)
+
+		evaluation := memoryProvider.StringEvaluation(ctx, "stringFlag", "default", nil)
+
+		if evaluation.Value != "default" {
+			t.Errorf("incorect evaluation, expected %s, got %s", "default", evaluation.Value)
+		}
+
+		if evaluation.Reason != openfeature.ErrorReason {
+			t.Errorf("incorect reason, expected %v, got %v", openfeature.ErrorReason, evaluation.Reason)
+		}
+
+		if evaluation.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+			t.Errorf("incorect error code, expected %v, got %v", openfeature.ParseErrorCode, evaluation.ResolutionDetail().ErrorCode)
+		}
+
+		intEvaluation := memoryProvider.IntEvaluation(ctx, "intFlag", 2, nil)
+		if intEvaluation.Value != 1 {
+			t.Errorf("incorect evaluation, expected %d, got %d", 1, intEvaluation.Value)
+		}
+	})
+
+	t.Run("global error", func(t *testing.T) {
+		memoryProvider := NewInMemoryProvider(flags,
+			WithSimulatedGlobalError(openfeature.NewGeneralResolutionError("simulated")),
+			WithSimulatedError("stringFlag", openfeature.NewParseErrorResolutionError("simulated")),
+		)
+
+		intEvaluation := memoryProvider.IntEvaluation(ctx, "intFlag", 2, nil)
+		if intEvaluation.Value != 2 {
+			t.Errorf("incorect evaluation, expected %d, got %d", 2, intEvaluation.Value)
+		}
+
+		if intEvaluation.ResolutionDetail().ErrorCode != openfeature.GeneralCode {
+			t.Errorf("incorect error code, expected %v, got %v", openfeature.GeneralCode, intEvaluation.ResolutionDetail().ErrorCode)
+		}
+
+		evaluation := memoryProvider.StringEvaluation(ctx, "stringFlag", "default", nil)
+		if evaluation.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+			t.Errorf("incorect error code, expected %v, got %v", openfeature.ParseErrorCode, evaluation.ResolutionDetail().ErrorCode)
+		}
+	})
+}
+
+func TestInMemoryProvider_FlagSchema(t *testing.T) {
+	memoryProvider := NewInMemoryProvider(map[string]InMemoryFlag{
+		"boolFlag": {
+			Key:            "boolFlag",
+			DefaultVariant: "on",
+			Variants:       map[string]interface{}{"on": true, "off": false},
+		},
+		"intFlag": {
+			Key:            "intFlag",
+			DefaultVariant: "one",
+			Variants:       map[string]interface{}{"one": 1, "two": 2},
+		},
+		"objectFlag": {
+			Key:            "objectFlag",
+			DefaultVariant: "config",
+			Variants:       map[string]interface{}{"config": map[string]interface{}{"key": "value"}},
+		},
+		"mixedFlag": {
+			Key:            "mixedFlag",
+			DefaultVariant: "a",
+			Variants:       map[string]interface{}{"a": "a", "b": 1.5},
+		},
+	})
+
+	schema := memoryProvider.FlagSchema()
+	expected := map[string]openfeature.Type{
+		"boolFlag":   openfeature.Boolean,
+		"intFlag":    openfeature.Int,
+		"objectFlag": openfeature.Object,
+	}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("incorrect schema, expected %v, got %v", expected, schema)
+	}
+}
@@ -0,0 +1,122 @@
+package memprovider
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// structVariant is the name of the single variant of flags built from a struct
+const structVariant = "value"
+
+// NewInMemoryProviderFromStruct builds an InMemoryProvider from a struct, e.g. a typed configuration object, so that
+// each exported field becomes a flag with the field value as single variant.
+//
+// Flags are keyed by field name, or by the value of the field's `flag` struct tag. Fields tagged `flag:"-"` and nil
+// pointer fields are skipped. Fields of nested structs become flags keyed by the key of the nested struct, a dot, and
+// their own key, unless the nested struct has no exported field (e.g. time.Time). Boolean, string, float and integer
+// fields become flags of the matching type; any other field (e.g. slices, maps and structs without exported fields)
+// becomes an object flag.
+func NewInMemoryProviderFromStruct(v interface{}, opts ...Option) (InMemoryProvider, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return InMemoryProvider{}, fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	flags := map[string]InMemoryFlag{}
+	if err := flagsFromStruct(value, "", flags); err != nil {
+		return InMemoryProvider{}, err
+	}
+
+	return NewInMemoryProvider(flags, opts...), nil
+}
+
+// flagsFromStruct adds a flag for each exported field of the struct to flags, prefixing keys with prefix
+func flagsFromStruct(value reflect.Value, prefix string, flags map[string]InMemoryFlag) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("flag"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				key = tag
+			}
+		}
+		key = prefix + key
+
+		fieldValue := value.Field(i)
+		for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		switch fieldValue.Kind() {
+		case reflect.Pointer:
+			// nil pointer, no value to serve
+			continue
+		case reflect.Struct:
+			// structs without exported fields, e.g. time.Time, are served as a whole
+			if hasExportedFields(fieldValue.Type()) {
+				if err := flagsFromStruct(fieldValue, key+".", flags); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if _, ok := flags[key]; ok {
+			return fmt.Errorf("duplicate flag key %s", key)
+		}
+		variant, err := variantValue(fieldValue)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+		flags[key] = InMemoryFlag{
+			Key:            key,
+			State:          Enabled,
+			DefaultVariant: structVariant,
+			Variants:       map[string]interface{}{structVariant: variant},
+		}
+	}
+
+	return nil
+}
+
+// hasExportedFields returns whether the struct type has at least one exported field
+func hasExportedFields(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		if structType.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// variantValue converts a field value to the type resolved by the evaluation of its flag type
+func variantValue(value reflect.Value) (interface{}, error) {
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := value.Uint()
+		if u > math.MaxInt {
+			return nil, fmt.Errorf("value %d overflows int", u)
+		}
+		return int(u), nil
+	default:
+		return value.Interface(), nil
+	}
+}
@@ -0,0 +1,91 @@
+package memprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type databaseConfig struct {
+	Host     string `flag:"host"`
+	PoolSize uint8  `flag:"pool-size"`
+}
+
+type appConfig struct {
+	DarkMode   bool
+	Ratio      float32 `flag:"ratio"`
+	Retries    int     `flag:"retries"`
+	Regions    []string
+	Database   databaseConfig `flag:"db"`
+	Cache      *databaseConfig
+	Ignored    string `flag:"-"`
+	Missing    *string
+	ReleasedAt time.Time `flag:"released-at"`
+	unexported string
+}
+
+func TestNewInMemoryProviderFromStruct(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewInMemoryProviderFromStruct(&appConfig{
+		DarkMode:   true,
+		Ratio:      0.5,
+		Retries:    3,
+		Regions:    []string{"eu", "us"},
+		Database:   databaseConfig{Host: "db.local", PoolSize: 10},
+		Cache:      &databaseConfig{Host: "cache.local"},
+		Ignored:    "ignored",
+		ReleasedAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if res := provider.BooleanEvaluation(ctx, "DarkMode", false, nil); !res.Value || res.Variant != structVariant {
+		t.Errorf("incorrect evaluation of DarkMode: %+v", res)
+	}
+	if res := provider.FloatEvaluation(ctx, "ratio", 0, nil); res.Value != 0.5 {
+		t.Errorf("incorrect evaluation of ratio: %+v", res)
+	}
+	if res := provider.IntEvaluation(ctx, "retries", 0, nil); res.Value != 3 {
+		t.Errorf("incorrect evaluation of retries: %+v", res)
+	}
+	if res := provider.ObjectEvaluation(ctx, "Regions", nil, nil); len(res.Value.([]string)) != 2 {
+		t.Errorf("incorrect evaluation of Regions: %+v", res)
+	}
+	if res := provider.StringEvaluation(ctx, "db.host", "", nil); res.Value != "db.local" {
+		t.Errorf("incorrect evaluation of db.host: %+v", res)
+	}
+	if res := provider.IntEvaluation(ctx, "db.pool-size", 0, nil); res.Value != 10 {
+		t.Errorf("incorrect evaluation of db.pool-size: %+v", res)
+	}
+	if res := provider.StringEvaluation(ctx, "Cache.host", "", nil); res.Value != "cache.local" {
+		t.Errorf("incorrect evaluation of Cache.host: %+v", res)
+	}
+
+	if res := provider.ObjectEvaluation(ctx, "released-at", nil, nil); res.Value != time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("incorrect evaluation of released-at: %+v", res)
+	}
+
+	for _, skipped := range []string{"Ignored", "Missing", "unexported"} {
+		res := provider.StringEvaluation(ctx, skipped, "", nil)
+		if res.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+			t.Errorf("expected flag %s to be skipped, got %+v", skipped, res)
+		}
+	}
+}
+
+func TestNewInMemoryProviderFromStructErrors(t *testing.T) {
+	if _, err := NewInMemoryProviderFromStruct("not a struct"); err == nil {
+		t.Error("expected an error for a non struct value")
+	}
+
+	duplicate := struct {
+		A string `flag:"key"`
+		B string `flag:"key"`
+	}{}
+	if _, err := NewInMemoryProviderFromStruct(duplicate); err == nil {
+		t.Error("expected an error for duplicate flag keys")
+	}
+}
@@ -0,0 +1,24 @@
+// Package middleware provides integrations feeding request data into OpenFeature evaluations.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// NewHTTPContextMiddleware returns an HTTP middleware extracting an evaluation context from each request, e.g. from
+// its headers, cookies or query, and merging it into the transaction context of the request context. Flag
+// evaluations of downstream handlers using the request context (r.Context()) then target the request without
+// passing the evaluation context around.
+//
+// The extracted context takes precedence over a transaction context already set on the request context by an
+// outer middleware.
+func NewHTTPContextMiddleware(extractor func(*http.Request) openfeature.EvaluationContext) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := openfeature.MergeTransactionContext(r.Context(), extractor(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
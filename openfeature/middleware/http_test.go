@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestNewHTTPContextMiddleware(t *testing.T) {
+	extractor := func(r *http.Request) openfeature.EvaluationContext {
+		return openfeature.NewEvaluationContext(r.Header.Get("X-User-Id"), map[string]interface{}{
+			"country": r.URL.Query().Get("country"),
+		})
+	}
+
+	var got openfeature.EvaluationContext
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = openfeature.TransactionContext(r.Context())
+	})
+
+	// an outer middleware already set a transaction context
+	outer := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := openfeature.WithTransactionContext(r.Context(), openfeature.NewTargetlessEvaluationContext(map[string]interface{}{
+				"country": "unknown",
+				"region":  "eu",
+			}))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/?country=fr", nil)
+	request.Header.Set("X-User-Id", "user-1")
+	outer(NewHTTPContextMiddleware(extractor)(handler)).ServeHTTP(httptest.NewRecorder(), request)
+
+	if got.TargetingKey() != "user-1" {
+		t.Errorf("expected targeting key %q, got %q", "user-1", got.TargetingKey())
+	}
+	if country := got.Attribute("country"); country != "fr" {
+		t.Errorf("expected the extracted country to take precedence, got %v", country)
+	}
+	if region := got.Attribute("region"); region != "eu" {
+		t.Errorf("expected the outer transaction context to be kept, got %v", region)
+	}
+}
@@ -0,0 +1,66 @@
+package openfeature
+
+import "context"
+
+// NormalizingProvider is a FeatureProvider decorator rewriting the reason and variant of the resolutions of the
+// wrapped provider, so that applications can present a uniform reason and variant taxonomy regardless of the
+// provider serving the flag. Lifecycle, eventing and tracking are delegated to the wrapped provider.
+type NormalizingProvider struct {
+	delegatingProvider
+	reasonMap  map[Reason]Reason
+	variantMap map[string]string
+}
+
+// NewNormalizingProvider wraps the given provider so that reasons and variants found in reasonMap and variantMap are
+// replaced by their mapped value. Unmapped reasons and variants pass through unchanged. Either map may be nil.
+func NewNormalizingProvider(delegate FeatureProvider, reasonMap map[Reason]Reason, variantMap map[string]string) *NormalizingProvider {
+	return &NormalizingProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: delegate},
+		reasonMap:          reasonMap,
+		variantMap:         variantMap,
+	}
+}
+
+// BooleanEvaluation evaluates a boolean flag and normalizes its reason and variant
+func (p *NormalizingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	res := p.FeatureProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.normalize(&res.ProviderResolutionDetail)
+	return res
+}
+
+// StringEvaluation evaluates a string flag and normalizes its reason and variant
+func (p *NormalizingProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	res := p.FeatureProvider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.normalize(&res.ProviderResolutionDetail)
+	return res
+}
+
+// FloatEvaluation evaluates a float flag and normalizes its reason and variant
+func (p *NormalizingProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	res := p.FeatureProvider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.normalize(&res.ProviderResolutionDetail)
+	return res
+}
+
+// IntEvaluation evaluates an int flag and normalizes its reason and variant
+func (p *NormalizingProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	res := p.FeatureProvider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.normalize(&res.ProviderResolutionDetail)
+	return res
+}
+
+// ObjectEvaluation evaluates an object flag and normalizes its reason and variant
+func (p *NormalizingProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	res := p.FeatureProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.normalize(&res.ProviderResolutionDetail)
+	return res
+}
+
+func (p *NormalizingProvider) normalize(detail *ProviderResolutionDetail) {
+	if reason, ok := p.reasonMap[detail.Reason]; ok {
+		detail.Reason = reason
+	}
+	if variant, ok := p.variantMap[detail.Variant]; ok {
+		detail.Variant = variant
+	}
+}
@@ -0,0 +1,44 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalizingProvider(t *testing.T) {
+	provider := NewNormalizingProvider(NoopProvider{},
+		map[Reason]Reason{DefaultReason: StaticReason},
+		map[string]string{"default-variant": "off"},
+	)
+
+	tests := map[string]ProviderResolutionDetail{
+		"boolean": provider.BooleanEvaluation(context.Background(), "flag", false, nil).ProviderResolutionDetail,
+		"string":  provider.StringEvaluation(context.Background(), "flag", "", nil).ProviderResolutionDetail,
+		"float":   provider.FloatEvaluation(context.Background(), "flag", 0, nil).ProviderResolutionDetail,
+		"int":     provider.IntEvaluation(context.Background(), "flag", 0, nil).ProviderResolutionDetail,
+		"object":  provider.ObjectEvaluation(context.Background(), "flag", nil, nil).ProviderResolutionDetail,
+	}
+
+	for name, detail := range tests {
+		t.Run(name, func(t *testing.T) {
+			if detail.Reason != StaticReason {
+				t.Errorf("expected reason %s, got %s", StaticReason, detail.Reason)
+			}
+			if detail.Variant != "off" {
+				t.Errorf("expected variant %s, got %s", "off", detail.Variant)
+			}
+		})
+	}
+
+	t.Run("unmapped values pass through", func(t *testing.T) {
+		provider := NewNormalizingProvider(NoopProvider{}, map[Reason]Reason{ErrorReason: DefaultReason}, nil)
+
+		detail := provider.BooleanEvaluation(context.Background(), "flag", false, nil)
+		if detail.Reason != DefaultReason {
+			t.Errorf("expected reason %s, got %s", DefaultReason, detail.Reason)
+		}
+		if detail.Variant != "default-variant" {
+			t.Errorf("expected variant %s, got %s", "default-variant", detail.Variant)
+		}
+	})
+}
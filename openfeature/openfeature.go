@@ -1,6 +1,12 @@
 package openfeature
 
-import "github.com/go-logr/logr"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+)
 
 // api is the global evaluationImpl implementation. This is a singleton and there can only be one instance.
 var api evaluationImpl
@@ -30,12 +36,82 @@ func SetProvider(provider FeatureProvider) error {
 	return api.SetProvider(provider)
 }
 
+// ErrProviderAlreadySet is returned by SetProviderOnce when a default provider was set already
+var ErrProviderAlreadySet = errors.New("default provider already set")
+
+// SetProviderOnce sets the default provider, failing with ErrProviderAlreadySet if a default provider was set
+// already, e.g. by a concurrent SetProvider during startup. This catches accidental double registrations and
+// init-ordering bugs; use ForceSetProvider to replace the default provider intentionally. Provider initialization is
+// asynchronous, as with SetProvider.
+func SetProviderOnce(provider FeatureProvider) error {
+	return api.SetProviderOnce(provider)
+}
+
+// ForceSetProvider sets the default provider, replacing any default provider set already. It behaves as
+// SetProvider, and documents an intentional replacement where SetProviderOnce guards the other registrations.
+func ForceSetProvider(provider FeatureProvider) error {
+	return api.ForceSetProvider(provider)
+}
+
+// SetProviderAndWaitForStates sets the default provider and waits until its state is one of the given states,
+// READY if none are given. This allows proceeding with a provider which is usable but not fully up-to-date, e.g.
+// accepting STALE for degraded-mode startup. Provider initialization is asynchronous, so that events emitted by the
+// provider during its initialization are taken into account. An error is returned if the provider reaches the ERROR
+// or FATAL state while those are not accepted.
+func SetProviderAndWaitForStates(provider FeatureProvider, states ...State) error {
+	return api.SetProviderAndWaitForStates(provider, states...)
+}
+
+// SetProviderWithMetadata sets the default provider, attaching operational metadata (e.g. region, team or
+// environment) to it. The metadata is added to the FlagMetadata of every evaluation and to the EventMetadata of every
+// event of the provider, including its initialization events, so that it travels with each decision without the
+// provider having to know about it. If the provider is also bound to other domains, the events received by the
+// handlers of those domains are not tagged. Metadata set by the provider itself takes precedence. Provider
+// initialization is asynchronous, as with SetProvider.
+func SetProviderWithMetadata(provider FeatureProvider, metadata map[string]interface{}) error {
+	return api.SetProviderWithMetadata(provider, metadata)
+}
+
+// SetProviderWithShutdownTimeout sets the default provider, bounding its shutdown by the given timeout. When the
+// provider is replaced, or on Shutdown, the provider's shutdown is waited for at most timeout, so that a slow provider
+// cannot hold up the whole shutdown sequence. Provider initialization is asynchronous, as with SetProvider.
+func SetProviderWithShutdownTimeout(provider FeatureProvider, timeout time.Duration) error {
+	return api.SetProviderWithShutdownTimeout(provider, timeout)
+}
+
+// SetProviderWithOptions sets the default provider, registered with the given options, e.g.
+// WithSuppressInitialReady. Provider initialization is asynchronous, as with SetProvider.
+func SetProviderWithOptions(provider FeatureProvider, options ...RegistrationOption) error {
+	return api.SetProviderWithOptions(provider, options...)
+}
+
 // SetProviderAndWait sets the default provider and waits for its initialization.
 // Returns an error if initialization cause error
 func SetProviderAndWait(provider FeatureProvider) error {
 	return api.SetProviderAndWait(provider)
 }
 
+// SetProviderAndWaitContext sets the default provider and waits for its initialization, or until ctx is done.
+// If ctx is done first, e.g. because the application is shutting down during startup, the initialization is
+// abandoned: an error wrapping the context error is returned, and the provider is shut down once its initialization
+// completes, so that a half-initialized provider does not leak resources. The provider is then unbound: the default
+// domain falls back to the NoopProvider, and a named domain to the default provider. Providers implementing
+// ContextInitializer are initialized with ctx, so that they can stop initializing as soon as it is done.
+func SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	return api.SetProviderAndWaitContext(ctx, provider)
+}
+
+// WaitUntilReady waits until the providers of the given domains are usable: READY, or STALE as a stale provider still
+// serves its last known flags. Unlike SetProviderAndWait, it is not tied to the registration of a provider, so that it
+// can gate serving traffic or back a readiness probe, with a deadline set on ctx. It returns nil only once all
+// providers are usable; an error is returned if ctx is done first or if a provider reaches the FATAL state.
+//
+// The default provider is waited for if it was set, or if no domain is given. Domains without a provider of their own
+// use the default provider. Until a default provider is set, the NoopProvider standing in for it counts as usable.
+func WaitUntilReady(ctx context.Context, domains ...string) error {
+	return api.WaitUntilReady(ctx, domains...)
+}
+
 // ProviderMetadata returns the default provider's metadata
 func ProviderMetadata() Metadata {
 	return api.GetProviderMetadata()
@@ -53,16 +129,66 @@ func SetNamedProviderAndWait(domain string, provider FeatureProvider) error {
 	return api.SetNamedProvider(domain, provider, false)
 }
 
+// SetNamedProviderAndWaitContext sets a provider mapped to the given Client domain and waits for its initialization,
+// or until ctx is done. See SetProviderAndWaitContext for the handling of abandoned initializations.
+func SetNamedProviderAndWaitContext(ctx context.Context, domain string, provider FeatureProvider) error {
+	return api.SetNamedProviderAndWaitContext(ctx, domain, provider)
+}
+
+// SetNamedProviderWithOptions sets a provider mapped to the given domain, registered with the given options, e.g.
+// WithSuppressInitialReady. Provider initialization is asynchronous, as with SetNamedProvider.
+func SetNamedProviderWithOptions(domain string, provider FeatureProvider, options ...RegistrationOption) error {
+	return api.SetNamedProviderWithOptions(domain, provider, options...)
+}
+
+// MarkReady emits the PROVIDER_READY event held for the domain by WithSuppressInitialReady, running the READY
+// handlers and moving the provider to the READY state. The empty domain designates the default provider. An error is
+// returned if no event is held, e.g. because the provider initialization is still in progress or failed.
+func MarkReady(domain string) error {
+	return api.MarkReady(domain)
+}
+
+// ProviderStats returns the evaluation counters of the provider used by clients of the given domain: the provider
+// bound to the domain, or the default provider if none is. Counters are reset when a provider is set for the domain.
+// They give basic provider health numbers (e.g. for a debug endpoint) without requiring a metrics hook.
+func ProviderStats(domain string) Stats {
+	return api.ProviderStats(domain)
+}
+
+// SubscribeEvaluations registers a callback receiving an EvaluationEvent with the details of every flag evaluation,
+// successful or not, of every client, e.g. to feed custom analytics without writing a hook. The returned function
+// unsubscribes the callback. Callbacks are called once the evaluation completed, before it returns to the caller.
+func SubscribeEvaluations(callback func(EvaluationEvent)) (unsubscribe func()) {
+	return api.SubscribeEvaluations(callback)
+}
+
 // NamedProviderMetadata returns the named provider's Metadata
 func NamedProviderMetadata(name string) Metadata {
 	return api.GetNamedProviderMetadata(name)
 }
 
+// AllClientsState returns the worst state among the default and all named providers, suitable for a single
+// readiness probe. States are ranked from worst to best as FATAL > ERROR > NOT_READY > STALE > READY.
+func AllClientsState() State {
+	return api.AllClientsState()
+}
+
 // SetEvaluationContext sets the global evaluation context.
 func SetEvaluationContext(evalCtx EvaluationContext) {
 	api.SetEvaluationContext(evalCtx)
 }
 
+// SetContextMergePrecedence sets the order in which the API, transaction, client and invocation evaluation contexts
+// are merged, from the lowest to the highest precedence. It defaults to DefaultContextMergePrecedence().
+//
+// This is an advanced feature deviating from the specification, meant for architectures in which the standard
+// precedence does not fit (e.g. the client context having the highest precedence). The evaluation context returned by
+// before hooks always has the highest precedence. The precedence must hold each ContextLevel exactly once, otherwise
+// an error is returned and the current precedence is kept.
+func SetContextMergePrecedence(precedence []ContextLevel) error {
+	return api.SetContextMergePrecedence(precedence)
+}
+
 // Deprecated
 // SetLogger sets the global Logger.
 func SetLogger(l logr.Logger) {
@@ -78,6 +204,28 @@ func AddHandler(eventType EventType, callback EventCallback) {
 	api.AddHandler(eventType, callback)
 }
 
+// SetEventHistory makes the API retain the size most recent provider events of each event type, so that handlers
+// added later with AddHandlerWithHistory receive them, e.g. a late subscriber interested in past configuration
+// changes. A size of 0, the default, disables the retention and discards retained events.
+func SetEventHistory(size int) {
+	api.SetEventHistory(size)
+}
+
+// ExplainHandlerRouting returns a human-readable explanation of how events of the given type reach the handlers of the
+// domain, to debug a handler which did not run: the provider bound to the domain, or the default provider it falls
+// back to, whether that provider emits events, the state of the domain and the number of API and client handlers
+// registered for the event type. It is meant for diagnostics, its format may change.
+func ExplainHandlerRouting(domain string, eventType EventType) string {
+	return api.ExplainHandlerRouting(domain, eventType)
+}
+
+// AddHandlerWithHistory allows to add API level event handler which, on registration, receives the retained events of
+// its type in order, see SetEventHistory. Without retained events, it behaves as AddHandler: it runs immediately if
+// the provider state matches the event type.
+func AddHandlerWithHistory(eventType EventType, callback EventCallback) {
+	api.AddHandlerWithHistory(eventType, callback)
+}
+
 // RemoveHandler allows to remove API level event handler
 func RemoveHandler(eventType EventType, callback EventCallback) {
 	api.RemoveHandler(eventType, callback)
@@ -1,9 +1,12 @@
 package openfeature
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/exp/maps"
@@ -20,6 +23,8 @@ type evaluationImpl interface {
 	SetLogger(l logr.Logger)
 
 	ForEvaluation(clientName string) (FeatureProvider, []Hook, EvaluationContext)
+	ContextMergePrecedence() []ContextLevel
+	recordEvaluation(clientName string, details InterfaceEvaluationDetails, err error)
 }
 
 // evaluationAPI wraps OpenFeature evaluation API functionalities
@@ -28,6 +33,12 @@ type evaluationAPI struct {
 	namedProviders  map[string]FeatureProvider
 	hks             []Hook
 	apiCtx          EvaluationContext
+	mergePrecedence []ContextLevel
+	stats           map[string]*providerStats
+	heldReady       map[string]heldReady
+	defaultSet      bool
+	subscribers     []evaluationSubscriber
+	subscriberID    uint64
 	eventExecutor   *eventExecutor
 	mu              sync.RWMutex
 }
@@ -39,6 +50,9 @@ func newEvaluationAPI(eventExecutor *eventExecutor) *evaluationAPI {
 		namedProviders:  map[string]FeatureProvider{},
 		hks:             []Hook{},
 		apiCtx:          EvaluationContext{},
+		mergePrecedence: DefaultContextMergePrecedence(),
+		stats:           map[string]*providerStats{defaultDomain: {}},
+		heldReady:       map[string]heldReady{},
 		mu:              sync.RWMutex{},
 		eventExecutor:   eventExecutor,
 	}
@@ -52,6 +66,62 @@ func (api *evaluationAPI) SetProviderAndWait(provider FeatureProvider) error {
 	return api.setProvider(provider, false)
 }
 
+// SetProviderOnce sets the default provider, unless a default provider was set already
+func (api *evaluationAPI) SetProviderOnce(provider FeatureProvider) error {
+	_, err := api.setDefaultProvider(context.Background(), provider, true, registrationOptions{once: true})
+	return err
+}
+
+// ForceSetProvider sets the default provider, replacing any default provider set already
+func (api *evaluationAPI) ForceSetProvider(provider FeatureProvider) error {
+	return api.setProvider(provider, true)
+}
+
+// SetProviderWithMetadata sets the default provider, attaching the given metadata to the flag metadata of every
+// evaluation and to the event metadata of every event of the provider
+func (api *evaluationAPI) SetProviderWithMetadata(provider FeatureProvider, metadata map[string]interface{}) error {
+	if provider == nil {
+		return errors.New("default provider cannot be set to nil")
+	}
+	return api.setProvider(newTaggingProvider(provider, metadata), true)
+}
+
+// SetProviderWithShutdownTimeout sets the default provider, bounding its shutdown by the given timeout
+func (api *evaluationAPI) SetProviderWithShutdownTimeout(provider FeatureProvider, timeout time.Duration) error {
+	if provider == nil {
+		return errors.New("default provider cannot be set to nil")
+	}
+	return api.setProvider(newShutdownTimeoutProvider(provider, timeout), true)
+}
+
+// SetProviderAndWaitForStates sets the default provider and waits until its state is one of the given states
+func (api *evaluationAPI) SetProviderAndWaitForStates(provider FeatureProvider, states ...State) error {
+	if len(states) == 0 {
+		states = []State{ReadyState}
+	}
+	accepted := func(state State) bool {
+		for _, s := range states {
+			if state == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := api.setProvider(provider, true); err != nil {
+		return err
+	}
+
+	// ERROR and FATAL end the wait, as the provider initialization failed
+	state, _ := api.eventExecutor.waitForState(context.Background(), defaultDomain, func(state State) bool {
+		return accepted(state) || state == ErrorState || state == FatalState
+	})
+	if !accepted(state) {
+		return fmt.Errorf("provider %s reached state %s while waiting for %v", provider.Metadata().Name, state, states)
+	}
+	return nil
+}
+
 // GetProviderMetadata returns the default FeatureProvider's metadata
 func (api *evaluationAPI) GetProviderMetadata() Metadata {
 	api.mu.RLock()
@@ -62,29 +132,212 @@ func (api *evaluationAPI) GetProviderMetadata() Metadata {
 
 // SetNamedProvider sets a provider with client name. Returns an error if FeatureProvider is nil
 func (api *evaluationAPI) SetNamedProvider(clientName string, provider FeatureProvider, async bool) error {
+	_, err := api.setNamedProvider(context.Background(), clientName, provider, async, registrationOptions{})
+	return err
+}
+
+// SetProviderWithOptions sets the default provider, registered with the given options. Provider initialization is
+// asynchronous.
+func (api *evaluationAPI) SetProviderWithOptions(provider FeatureProvider, options ...RegistrationOption) error {
+	_, err := api.setDefaultProvider(context.Background(), provider, true, newRegistrationOptions(options))
+	return err
+}
+
+// SetNamedProviderWithOptions sets a provider mapped to the given domain, registered with the given options.
+// Provider initialization is asynchronous.
+func (api *evaluationAPI) SetNamedProviderWithOptions(clientName string, provider FeatureProvider, options ...RegistrationOption) error {
+	_, err := api.setNamedProvider(context.Background(), clientName, provider, true, newRegistrationOptions(options))
+	return err
+}
+
+// MarkReady emits the PROVIDER_READY event held for the domain, see WithSuppressInitialReady. The empty domain
+// designates the default provider. An error is returned if no event is held, e.g. because the initialization is still
+// in progress or failed.
+func (api *evaluationAPI) MarkReady(domain string) error {
+	api.mu.Lock()
+	held, ok := api.heldReady[domain]
+	delete(api.heldReady, domain)
+	api.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no ready event held for domain %q", domain)
+	}
+
+	api.eventExecutor.storeState(domain, ReadyState)
+	api.eventExecutor.triggerEvent(held.event, held.provider)
+	return nil
+}
+
+// holdReady holds the ready event of the provider until MarkReady is called, unless the provider was replaced
+func (api *evaluationAPI) holdReady(domain string, provider FeatureProvider, event Event) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	bound := api.defaultProvider
+	if domain != defaultDomain {
+		bound = api.namedProviders[domain]
+	}
+	if bound != provider {
+		return
+	}
+	api.heldReady[domain] = heldReady{event: event, provider: provider}
+}
+
+func newRegistrationOptions(options []RegistrationOption) registrationOptions {
+	var registration registrationOptions
+	for _, option := range options {
+		option(&registration)
+	}
+	return registration
+}
+
+// SetProviderAndWaitContext sets the default provider and waits for its initialization, or until ctx is done
+func (api *evaluationAPI) SetProviderAndWaitContext(ctx context.Context, provider FeatureProvider) error {
+	initialized, err := api.setDefaultProvider(ctx, provider, true, registrationOptions{})
+	if err != nil {
+		return err
+	}
+	return api.waitForInitialization(ctx, defaultDomain, provider, initialized)
+}
+
+// SetNamedProviderAndWaitContext sets a provider mapped to the given domain and waits for its initialization, or
+// until ctx is done
+func (api *evaluationAPI) SetNamedProviderAndWaitContext(ctx context.Context, clientName string, provider FeatureProvider) error {
+	initialized, err := api.setNamedProvider(ctx, clientName, provider, true, registrationOptions{})
+	if err != nil {
+		return err
+	}
+	return api.waitForInitialization(ctx, clientName, provider, initialized)
+}
+
+// waitForInitialization waits for the initialization of the provider bound to the domain. If ctx is done first, the
+// initialization is abandoned: once it completes, the provider is unbound and shut down, unless it was replaced in
+// the meantime, see abandonInitialization.
+func (api *evaluationAPI) waitForInitialization(
+	ctx context.Context, domain string, provider FeatureProvider, initialized <-chan struct{},
+) error {
+	select {
+	case <-initialized:
+	case <-ctx.Done():
+	}
+	// an initialization failing because ctx is done is abandoned as well
+	if err := ctx.Err(); err != nil {
+		go api.abandonInitialization(domain, provider, initialized)
+		return fmt.Errorf("initialization of provider %s abandoned: %w", provider.Metadata().Name, err)
+	}
+
+	if state := api.eventExecutor.State(domain); state == ErrorState || state == FatalState {
+		return fmt.Errorf("provider %s reached state %s during initialization", provider.Metadata().Name, state)
+	}
+	return nil
+}
+
+// WaitUntilReady waits until the providers of the given domains are usable, i.e. READY or STALE, or until ctx is done.
+// The default provider is waited for as well if it was set, or if no domain is given.
+func (api *evaluationAPI) WaitUntilReady(ctx context.Context, domains ...string) error {
+	settled := func(state State) bool {
+		return state == ReadyState || state == StaleState || state == FatalState
+	}
+
+	api.mu.RLock()
+	defaultSet := api.defaultSet
+	bound := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		_, bound[domain] = api.namedProviders[domain]
+	}
+	api.mu.RUnlock()
+
+	if defaultSet || len(domains) == 0 {
+		domains = append([]string{defaultDomain}, domains...)
+	}
+	for _, domain := range domains {
+		// the NoopProvider standing in for an unset default provider is usable
+		if !defaultSet && !bound[domain] {
+			continue
+		}
+		state, err := api.eventExecutor.waitForState(ctx, domain, settled)
+		if err != nil {
+			return fmt.Errorf("provider of domain %q not ready, state %s: %w", domain, state, err)
+		}
+		// FATAL is final, there is no point in waiting longer
+		if state == FatalState {
+			return fmt.Errorf("provider of domain %q reached state %s", domain, state)
+		}
+	}
+	return nil
+}
+
+// abandonInitialization unbinds the provider once its initialization completes, if it is still bound to the domain,
+// and shuts it down unless it is bound to another domain. A named domain then falls back to the default provider, and
+// the default domain to the NoopProvider, READY as when no provider was set. If the provider was replaced in the
+// meantime, the provider replacing it already took care of the shutdown.
+func (api *evaluationAPI) abandonInitialization(domain string, provider FeatureProvider, initialized <-chan struct{}) {
+	<-initialized
+
+	api.mu.Lock()
+	var err error
+	if domain == defaultDomain {
+		if api.defaultProvider != provider {
+			api.mu.Unlock()
+			return
+		}
+		api.defaultProvider = NoopProvider{}
+		api.defaultSet = false
+		api.stats[domain] = &providerStats{}
+		err = api.eventExecutor.registerDefaultProvider(api.defaultProvider)
+		api.eventExecutor.storeState(domain, ReadyState)
+	} else {
+		if api.namedProviders[domain] != provider {
+			api.mu.Unlock()
+			return
+		}
+		delete(api.namedProviders, domain)
+		delete(api.stats, domain)
+		err = api.eventExecutor.unregisterNamedEventingProvider(domain)
+	}
+	delete(api.heldReady, domain)
+
+	// check for multiple bindings
+	bound := untagged(provider) == untagged(api.defaultProvider) || contains(provider, maps.Values(api.namedProviders))
+	api.mu.Unlock()
+
+	if err != nil {
+		slog.Warn("failed to unbind the events of an abandoned provider", "provider", provider.Metadata().Name, "error", err)
+	}
+	if handler, ok := provider.(StateHandler); ok && !bound {
+		handler.Shutdown()
+	}
+}
+
+// setNamedProvider binds the provider to the client name. The returned channel is closed once the provider
+// initialization completed.
+func (api *evaluationAPI) setNamedProvider(
+	ctx context.Context, clientName string, provider FeatureProvider, async bool, options registrationOptions,
+) (<-chan struct{}, error) {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
 	if provider == nil {
-		return errors.New("provider cannot be set to nil")
+		return nil, errors.New("provider cannot be set to nil")
 	}
 
 	// Initialize new named provider and Shutdown the old one
 	// Provider update must be non-blocking, hence initialization & Shutdown happens concurrently
 	oldProvider := api.namedProviders[clientName]
 	api.namedProviders[clientName] = provider
+	api.stats[clientName] = &providerStats{}
 
-	err := api.initNewAndShutdownOld(clientName, provider, oldProvider, async)
+	initialized, err := api.initNewAndShutdownOld(ctx, clientName, provider, oldProvider, async, options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = api.eventExecutor.registerNamedEventingProvider(clientName, provider)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return initialized, nil
 }
 
 // GetNamedProviderMetadata returns the default FeatureProvider's metadata
@@ -100,6 +353,87 @@ func (api *evaluationAPI) GetNamedProviderMetadata(name string) Metadata {
 	return provider.Metadata()
 }
 
+// AllClientsState returns the aggregated state of the default and all named providers, which is the worst state among
+// them. States are ranked from worst to best as FATAL > ERROR > NOT_READY > STALE > READY.
+func (api *evaluationAPI) AllClientsState() State {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+
+	state := api.eventExecutor.State(defaultDomain)
+	for domain := range api.namedProviders {
+		if domainState := api.eventExecutor.State(domain); domainState.IsWorseThan(state) {
+			state = domainState
+		}
+	}
+
+	return state
+}
+
+// ProviderStats returns the evaluation counters of the provider used by clients of the given domain, since it was set
+func (api *evaluationAPI) ProviderStats(domain string) Stats {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+
+	return api.statsFor(domain).snapshot()
+}
+
+// SubscribeEvaluations registers a callback receiving an EvaluationEvent for every flag evaluation. The returned
+// function unsubscribes the callback.
+func (api *evaluationAPI) SubscribeEvaluations(callback func(EvaluationEvent)) (unsubscribe func()) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.subscriberID++
+	id := api.subscriberID
+	api.subscribers = append(api.subscribers, evaluationSubscriber{id: id, callback: callback})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			for i, subscriber := range api.subscribers {
+				if subscriber.id == id {
+					// copy, as the current subscribers may be in use by a publication
+					api.subscribers = append(api.subscribers[:i:i], api.subscribers[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// recordEvaluation counts an evaluation of a client of the given domain in the stats of the provider it used, and
+// publishes it to the evaluation subscribers
+func (api *evaluationAPI) recordEvaluation(clientName string, details InterfaceEvaluationDetails, err error) {
+	api.mu.RLock()
+	stats := api.statsFor(clientName)
+	subscribers := api.subscribers
+	api.mu.RUnlock()
+
+	stats.record(err)
+
+	if len(subscribers) == 0 {
+		return
+	}
+	event := EvaluationEvent{Domain: clientName, Details: details, Error: err}
+	for _, subscriber := range subscribers {
+		runEvaluationCallback("evaluation subscriber", details.FlagKey, func() {
+			subscriber.callback(event)
+		})
+	}
+}
+
+// statsFor returns the stats of the provider bound to the domain, or of the default provider if none is.
+// Must be called with the lock held.
+func (api *evaluationAPI) statsFor(domain string) *providerStats {
+	if stats, ok := api.stats[domain]; ok {
+		return stats
+	}
+	return api.stats[defaultDomain]
+}
+
 // GetNamedProviders returns named providers map.
 func (api *evaluationAPI) GetNamedProviders() map[string]FeatureProvider {
 	api.mu.RLock()
@@ -149,26 +483,76 @@ func (api *evaluationAPI) AddHandler(eventType EventType, callback EventCallback
 	api.eventExecutor.AddHandler(eventType, callback)
 }
 
+// AddHandlerWithHistory allows to add API level event handler receiving the retained events of its type
+func (api *evaluationAPI) AddHandlerWithHistory(eventType EventType, callback EventCallback) {
+	api.eventExecutor.AddHandlerWithHistory(eventType, callback)
+}
+
+// SetEventHistory sets the number of most recent events retained per event type
+func (api *evaluationAPI) SetEventHistory(size int) {
+	api.eventExecutor.SetEventHistory(size)
+}
+
+// ExplainHandlerRouting describes how events of the given type reach the handlers of the domain
+func (api *evaluationAPI) ExplainHandlerRouting(domain string, eventType EventType) string {
+	return api.eventExecutor.explainHandlerRouting(domain, eventType)
+}
+
 // RemoveHandler allows to remove API level event handler
 func (api *evaluationAPI) RemoveHandler(eventType EventType, callback EventCallback) {
 	api.eventExecutor.RemoveHandler(eventType, callback)
 }
 
+// Shutdown shuts down the default and named providers concurrently, and waits for all of them to complete. Providers
+// set with a shutdown timeout are waited for at most their own timeout.
 func (api *evaluationAPI) Shutdown() {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
-	v, ok := api.defaultProvider.(StateHandler)
-	if ok {
-		v.Shutdown()
+	// a provider bound to several domains is shut down once
+	providers := []FeatureProvider{api.defaultProvider}
+	for _, provider := range api.namedProviders {
+		if !contains(provider, providers) {
+			providers = append(providers, provider)
+		}
 	}
 
-	for _, provider := range api.namedProviders {
-		v, ok = provider.(StateHandler)
-		if ok {
-			v.Shutdown()
+	var wg sync.WaitGroup
+	for _, provider := range providers {
+		v, ok := provider.(StateHandler)
+		if !ok {
+			continue
 		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v.Shutdown()
+		}()
 	}
+
+	wg.Wait()
+}
+
+// SetContextMergePrecedence sets the order in which evaluation contexts are merged, from the lowest to the highest
+// precedence. The precedence must hold each ContextLevel exactly once.
+func (api *evaluationAPI) SetContextMergePrecedence(precedence []ContextLevel) error {
+	if err := validateContextMergePrecedence(precedence); err != nil {
+		return err
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.mergePrecedence = append([]ContextLevel(nil), precedence...)
+	return nil
+}
+
+// ContextMergePrecedence returns the order in which evaluation contexts are merged
+func (api *evaluationAPI) ContextMergePrecedence() []ContextLevel {
+	api.mu.RLock()
+	defer api.mu.RUnlock()
+
+	return append([]ContextLevel(nil), api.mergePrecedence...)
 }
 
 // ForEvaluation is a helper to retrieve transaction scoped operators.
@@ -198,44 +582,79 @@ func (api *evaluationAPI) GetProvider() FeatureProvider {
 // SetProvider sets the default FeatureProvider of the evaluationAPI.
 // Returns an error if provider registration cause an error
 func (api *evaluationAPI) setProvider(provider FeatureProvider, async bool) error {
+	_, err := api.setDefaultProvider(context.Background(), provider, async, registrationOptions{})
+	return err
+}
+
+// setDefaultProvider sets the default FeatureProvider. The returned channel is closed once the provider
+// initialization completed.
+func (api *evaluationAPI) setDefaultProvider(
+	ctx context.Context, provider FeatureProvider, async bool, options registrationOptions,
+) (<-chan struct{}, error) {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
 	if provider == nil {
-		return errors.New("default provider cannot be set to nil")
+		return nil, errors.New("default provider cannot be set to nil")
+	}
+	if options.once && api.defaultSet {
+		return nil, fmt.Errorf("%w: %s", ErrProviderAlreadySet, api.defaultProvider.Metadata().Name)
 	}
 
 	oldProvider := api.defaultProvider
+	api.defaultSet = true
 	api.defaultProvider = provider
+	api.stats[defaultDomain] = &providerStats{}
 
-	err := api.initNewAndShutdownOld("", provider, oldProvider, async)
+	initialized, err := api.initNewAndShutdownOld(ctx, "", provider, oldProvider, async, options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = api.eventExecutor.registerDefaultProvider(provider)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return initialized, nil
 }
 
 // initNewAndShutdownOld is a helper to initialise new FeatureProvider and Shutdown the old FeatureProvider.
-func (api *evaluationAPI) initNewAndShutdownOld(clientName string, newProvider FeatureProvider, oldProvider FeatureProvider, async bool) error {
+// The returned channel is closed once the initialization of the new provider completed.
+func (api *evaluationAPI) initNewAndShutdownOld(
+	ctx context.Context, clientName string, newProvider FeatureProvider, oldProvider FeatureProvider, async bool,
+	options registrationOptions,
+) (<-chan struct{}, error) {
+	// a ready event held for the replaced provider is dropped
+	delete(api.heldReady, clientName)
+
+	initialized := make(chan struct{})
 	if async {
-		go func(executor *eventExecutor, ctx EvaluationContext) {
+		// the new provider is not ready until its initialization completes. A provider without initialization is ready
+		// right away, so that evaluations following SetProvider do not see it NOT_READY.
+		state := NotReadyState
+		if !requiresInitialization(newProvider) && !options.suppressInitialReady {
+			state = ReadyState
+		}
+		api.eventExecutor.storeState(clientName, state)
+		go func(executor *eventExecutor, evalCtx EvaluationContext) {
+			defer close(initialized)
 			// for async initialization, error is conveyed as an event
-			event, _ := initializer(newProvider, ctx)
-			executor.states.Store(clientName, stateFromEventOrError(event, nil))
+			event, _ := initializer(ctx, newProvider, evalCtx)
+			if options.suppressInitialReady && event.EventType == ProviderReady {
+				api.holdReady(clientName, newProvider, event)
+				return
+			}
+			executor.storeState(clientName, stateFromEventOrError(event, nil))
 			executor.triggerEvent(event, newProvider)
 		}(api.eventExecutor, api.apiCtx)
 	} else {
-		event, err := initializer(newProvider, api.apiCtx)
-		api.eventExecutor.states.Store(clientName, stateFromEventOrError(event, err))
+		event, err := initializer(ctx, newProvider, api.apiCtx)
+		api.eventExecutor.storeState(clientName, stateFromEventOrError(event, err))
 		api.eventExecutor.triggerEvent(event, newProvider)
+		close(initialized)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -243,24 +662,35 @@ func (api *evaluationAPI) initNewAndShutdownOld(clientName string, newProvider F
 
 	// oldProvider can be nil or without state handling capability
 	if oldProvider == nil || !ok {
-		return nil
+		return initialized, nil
 	}
 
 	// check for multiple bindings
-	if oldProvider == api.defaultProvider || contains(oldProvider, maps.Values(api.namedProviders)) {
-		return nil
+	if untagged(oldProvider) == untagged(api.defaultProvider) || contains(oldProvider, maps.Values(api.namedProviders)) {
+		return initialized, nil
 	}
 
 	go func(forShutdown StateHandler) {
 		forShutdown.Shutdown()
 	}(v)
 
-	return nil
+	return initialized, nil
+}
+
+// requiresInitialization returns whether the provider has an initialization to run before being ready, looking
+// through decorators
+func requiresInitialization(provider FeatureProvider) bool {
+	if d, ok := provider.(decorator); ok {
+		return d.requiresInitialization()
+	}
+	_, isInitializer := provider.(ContextInitializer)
+	_, isStateHandler := provider.(StateHandler)
+	return isInitializer || isStateHandler
 }
 
 // initializer is a helper to execute provider initialization and generate appropriate event for the initialization
 // It also returns an error if the initialization resulted in an error
-func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, error) {
+func initializer(ctx context.Context, provider FeatureProvider, apiCtx EvaluationContext) (Event, error) {
 	var event = Event{
 		ProviderName: provider.Metadata().Name,
 		EventType:    ProviderReady,
@@ -269,13 +699,16 @@ func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, err
 		},
 	}
 
-	handler, ok := provider.(StateHandler)
-	if !ok {
+	var err error
+	if initializer, ok := provider.(ContextInitializer); ok {
+		err = initializer.InitWithContext(ctx, apiCtx)
+	} else if handler, ok := provider.(StateHandler); ok {
+		err = handler.Init(apiCtx)
+	} else {
 		// Note - a provider without state handling capability can be assumed to be ready immediately.
 		return event, nil
 	}
 
-	err := handler.Init(apiCtx)
 	if err != nil {
 		event.EventType = ProviderError
 		event.Message = fmt.Sprintf("Provider initialization error, %v", err)
@@ -293,7 +726,7 @@ func initializer(provider FeatureProvider, apiCtx EvaluationContext) (Event, err
 
 func contains(provider FeatureProvider, in []FeatureProvider) bool {
 	for _, p := range in {
-		if provider == p {
+		if untagged(provider) == untagged(p) {
 			return true
 		}
 	}
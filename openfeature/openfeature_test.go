@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -546,7 +547,7 @@ func TestRequirement_1_1_6(t *testing.T) {
 // The client creation function MUST NOT throw, or otherwise abnormally terminate.
 func TestRequirement_1_1_7(t *testing.T) {
 	defer t.Cleanup(initSingleton)
-	type clientCreationFunc func(name string) *Client
+	type clientCreationFunc func(name string, options ...ClientOption) *Client
 
 	// asserting that our NewClient method matches this signature is enough to deduce that no error is returned
 	var f clientCreationFunc = NewClient
@@ -776,6 +777,55 @@ func TestForNilProviders(t *testing.T) {
 	}
 }
 
+func TestAllClientsState(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	if state := AllClientsState(); state != NotReadyState {
+		t.Errorf("expected %s state before any provider is set, got %s", NotReadyState, state)
+	}
+
+	if err := SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	if err := SetNamedProviderAndWait("ready", struct {
+		FeatureProvider
+		StateHandler
+	}{NoopProvider{}, &stateHandlerForTests{}}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	if state := AllClientsState(); state != ReadyState {
+		t.Errorf("expected %s state, got %s", ReadyState, state)
+	}
+
+	_ = SetNamedProviderAndWait("error", struct {
+		FeatureProvider
+		StateHandler
+	}{NoopProvider{}, &stateHandlerForTests{
+		initF: func(e EvaluationContext) error {
+			return errors.New("init error")
+		},
+	}})
+
+	if state := AllClientsState(); state != ErrorState {
+		t.Errorf("expected %s state, got %s", ErrorState, state)
+	}
+
+	_ = SetNamedProviderAndWait("fatal", struct {
+		FeatureProvider
+		StateHandler
+	}{NoopProvider{}, &stateHandlerForTests{
+		initF: func(e EvaluationContext) error {
+			return &ProviderInitError{ErrorCode: ProviderFatalCode}
+		},
+	}})
+
+	if state := AllClientsState(); state != FatalState {
+		t.Errorf("expected %s state, got %s", FatalState, state)
+	}
+}
+
 func use(vals ...interface{}) {
 	for _, val := range vals {
 		_ = val
@@ -819,3 +869,468 @@ func setupProviderWithSemaphores() (struct {
 
 	return provider, intiSem, shutdownSem
 }
+
+func TestSetProviderAndWaitForStates(t *testing.T) {
+	t.Run("accepts a provider becoming stale during its initialization", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+
+		eventing := &ProviderEventing{c: make(chan Event, 1)}
+		release := make(chan struct{})
+		defer close(release)
+		provider := struct {
+			FeatureProvider
+			StateHandler
+			EventHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					// has cached data, but cannot refresh it
+					eventing.Invoke(Event{EventType: ProviderStale})
+					<-release
+					return nil
+				},
+			},
+			eventing,
+		}
+
+		if err := api.SetProviderAndWaitForStates(provider, ReadyState, StaleState); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state := executor.State(defaultDomain); state != StaleState {
+			t.Errorf("expected state %s, got %s", StaleState, state)
+		}
+	})
+
+	t.Run("fails when the provider initialization fails", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					return errors.New("init failed")
+				},
+			},
+		}
+
+		if err := api.SetProviderAndWaitForStates(provider, ReadyState, StaleState); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestSetProviderAndWaitContext(t *testing.T) {
+	t.Run("waits for the provider initialization", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+
+		if err := api.SetProviderAndWaitContext(context.Background(), NoopProvider{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state := executor.State(defaultDomain); state != ReadyState {
+			t.Errorf("expected state %s, got %s", ReadyState, state)
+		}
+	})
+
+	t.Run("fails when the provider initialization fails", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					return errors.New("init failed")
+				},
+			},
+		}
+
+		if err := api.SetNamedProviderAndWaitContext(context.Background(), t.Name(), provider); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("canceling mid-init shuts the provider down", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+		defaultProvider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+			return true, ProviderResolutionDetail{Reason: StaticReason}
+		})
+		if err := api.SetProviderAndWait(defaultProvider); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		initStarted := make(chan struct{})
+		release := make(chan struct{})
+		shutdown := make(chan struct{})
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					close(initStarted)
+					<-release
+					return nil
+				},
+				shutdownF: func() {
+					close(shutdown)
+				},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-initStarted
+			cancel()
+		}()
+
+		err := api.SetNamedProviderAndWaitContext(ctx, t.Name(), provider)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected a context canceled error, got %v", err)
+		}
+
+		select {
+		case <-shutdown:
+			t.Fatal("expected the shutdown to wait for the initialization to complete")
+		default:
+		}
+
+		close(release)
+		select {
+		case <-shutdown:
+		case <-time.After(time.Second):
+			t.Fatal("expected the abandoned provider to be shut down")
+		}
+
+		// the domain falls back to the default provider
+		if _, ok := api.GetNamedProviders()[t.Name()]; ok {
+			t.Error("expected the abandoned provider to be unbound")
+		}
+		client := newClient(t.Name(), api, executor)
+		if value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}); err != nil || !value {
+			t.Errorf("expected the default provider to evaluate the flag, got %t and %v", value, err)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := api.WaitUntilReady(ctx, t.Name()); err != nil {
+			t.Errorf("expected the domain to be ready, got %v", err)
+		}
+	})
+
+	t.Run("canceling mid-init, then replacing the provider shuts it down once", func(t *testing.T) {
+		executor := newEventExecutor()
+		api := newEvaluationAPI(executor)
+
+		initStarted := make(chan struct{})
+		release := make(chan struct{})
+		var mu sync.Mutex
+		shutdowns := 0
+		provider := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					close(initStarted)
+					<-release
+					return nil
+				},
+				shutdownF: func() {
+					mu.Lock()
+					defer mu.Unlock()
+					shutdowns++
+				},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-initStarted
+			cancel()
+		}()
+
+		err := api.SetProviderAndWaitContext(ctx, provider)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected a context canceled error, got %v", err)
+		}
+
+		close(release)
+		eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return shutdowns == 1
+		}, time.Second, 10*time.Millisecond, "expected the abandoned provider to be shut down")
+		if bound := api.GetProvider(); bound != (NoopProvider{}) {
+			t.Errorf("expected the abandoned provider to be unbound, got %v", bound)
+		}
+		if state := executor.State(defaultDomain); state != ReadyState {
+			t.Errorf("expected state %s, got %s", ReadyState, state)
+		}
+		client := newClient(t.Name(), api, executor)
+		if _, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}); err != nil {
+			t.Errorf("unexpected error evaluating after the abandoned initialization: %v", err)
+		}
+
+		if err := api.SetProviderAndWait(NoopProvider{}); err != nil {
+			t.Fatalf("failed to replace the provider: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if shutdowns != 1 {
+			t.Errorf("expected the abandoned provider to be shut down once, got %d shutdowns", shutdowns)
+		}
+	})
+}
+
+// contextInitProvider is a provider whose initialization blocks until its context is done
+type contextInitProvider struct {
+	NoopProvider
+	shutdown chan struct{}
+}
+
+func (p contextInitProvider) InitWithContext(ctx context.Context, _ EvaluationContext) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (p contextInitProvider) Init(EvaluationContext) error {
+	panic("Init must not be called for a ContextInitializer")
+}
+
+func (p contextInitProvider) Shutdown() {
+	close(p.shutdown)
+}
+
+func TestContextInitializer(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := contextInitProvider{shutdown: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := api.SetProviderAndWaitContext(ctx, provider)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+
+	// the initialization honors the deadline, so the abandoned provider is shut down right away
+	select {
+	case <-provider.shutdown:
+	case <-time.After(time.Second):
+		t.Fatal("expected the abandoned provider to be shut down")
+	}
+}
+
+func TestContextInitializerThroughDecorator(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewTimeoutProvider(contextInitProvider{shutdown: make(chan struct{})}, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := api.SetNamedProviderAndWaitContext(ctx, t.Name(), provider); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context canceled error, got %v", err)
+	}
+}
+
+func TestSuppressInitialReady(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	initialized := make(chan struct{})
+	provider := struct {
+		FeatureProvider
+		StateHandler
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{
+			initF: func(e EvaluationContext) error {
+				close(initialized)
+				return nil
+			},
+		},
+	}
+
+	ready := make(chan EventDetails, 1)
+	callback := func(details EventDetails) {
+		ready <- details
+	}
+	executor.AddClientHandler(t.Name(), ProviderReady, &callback)
+
+	if err := api.SetNamedProviderWithOptions(t.Name(), provider, WithSuppressInitialReady()); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	<-initialized
+
+	select {
+	case <-ready:
+		t.Fatal("expected the ready event to be held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if state := executor.State(t.Name()); state != NotReadyState {
+		t.Errorf("expected the provider to stay %s, got %s", NotReadyState, state)
+	}
+
+	if err := api.MarkReady(t.Name()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-ready:
+	case <-time.After(time.Second):
+		t.Fatal("expected the ready event to be emitted")
+	}
+	if state := executor.State(t.Name()); state != ReadyState {
+		t.Errorf("expected the provider to be %s, got %s", ReadyState, state)
+	}
+
+	if err := api.MarkReady(t.Name()); err == nil {
+		t.Error("expected an error, as the ready event was already emitted")
+	}
+}
+
+func TestSetProviderOnce(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- api.SetProviderOnce(NoopProvider{})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var succeeded int
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrProviderAlreadySet):
+			t.Errorf("expected ErrProviderAlreadySet, got %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("expected exactly one registration to succeed, got %d", succeeded)
+	}
+
+	if err := api.ForceSetProvider(NoopProvider{}); err != nil {
+		t.Errorf("expected the forced registration to succeed, got %v", err)
+	}
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	domain := t.Name()
+	release := make(chan struct{})
+	provider := struct {
+		FeatureProvider
+		StateHandler
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{
+			initF: func(e EvaluationContext) error {
+				<-release
+				return nil
+			},
+		},
+	}
+	if err := api.SetProvider(NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	if err := api.SetNamedProvider(domain, provider, true); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	t.Run("default provider ready", func(t *testing.T) {
+		if err := api.WaitUntilReady(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("named provider initializing", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if err := api.WaitUntilReady(ctx, domain); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a deadline exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("named provider initialized", func(t *testing.T) {
+		close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := api.WaitUntilReady(ctx, domain); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("fatal provider", func(t *testing.T) {
+		fatal := struct {
+			FeatureProvider
+			StateHandler
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					return &ProviderInitError{ErrorCode: ProviderFatalCode, Message: "fatal"}
+				},
+			},
+		}
+		if err := api.SetNamedProvider(t.Name(), fatal, true); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err := api.WaitUntilReady(ctx, t.Name())
+		if err == nil || errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the FATAL state to end the wait, got %v", err)
+		}
+	})
+}
+
+func TestWaitUntilReadyWithoutDefaultProvider(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetNamedProviderAndWaitContext(context.Background(), t.Name(), NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := api.WaitUntilReady(ctx, t.Name()); err != nil {
+		t.Errorf("expected the named provider to be ready, got %v", err)
+	}
+	if err := api.WaitUntilReady(ctx); err != nil {
+		t.Errorf("expected the NoopProvider in place of the default provider to be usable, got %v", err)
+	}
+	if err := api.WaitUntilReady(ctx, "unbound"); err != nil {
+		t.Errorf("expected a domain falling back to the NoopProvider to be usable, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// OverrideProvider is a FeatureProvider decorator serving values forced at runtime for some flags, e.g. to turn
+// kill-switch flags off during an incident without redeploying. Other flags are resolved by the wrapped provider.
+// Lifecycle, eventing and tracking are delegated to the wrapped provider.
+type OverrideProvider struct {
+	delegatingProvider
+
+	mu        sync.RWMutex
+	overrides map[string]interface{}
+
+	events       chan Event
+	forwardOnce  sync.Once
+	done         chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewOverrideProvider wraps the given provider with a live override layer, see SetOverride
+func NewOverrideProvider(delegate FeatureProvider) *OverrideProvider {
+	return &OverrideProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: delegate},
+		overrides:          map[string]interface{}{},
+		events:             make(chan Event, 5),
+		done:               make(chan struct{}),
+	}
+}
+
+// SetOverride forces the flag to resolve to value, with the OVERRIDE reason, until the override is cleared.
+// The value must be of the type the flag is evaluated with (bool, string, float64, int64 or int, any value for object
+// flags), otherwise evaluations fail with a TYPE_MISMATCH error. A PROVIDER_CONFIGURATION_CHANGED event is emitted,
+// so that clients refresh.
+func (p *OverrideProvider) SetOverride(flagKey string, value interface{}) {
+	p.mu.Lock()
+	p.overrides[flagKey] = value
+	p.mu.Unlock()
+
+	p.emitChange(flagKey, fmt.Sprintf("override set for flag %s", flagKey))
+}
+
+// ClearOverride removes the override of the flag, which is resolved by the wrapped provider again.
+// A PROVIDER_CONFIGURATION_CHANGED event is emitted if the flag was overridden.
+func (p *OverrideProvider) ClearOverride(flagKey string) {
+	p.mu.Lock()
+	_, ok := p.overrides[flagKey]
+	delete(p.overrides, flagKey)
+	p.mu.Unlock()
+
+	if ok {
+		p.emitChange(flagKey, fmt.Sprintf("override cleared for flag %s", flagKey))
+	}
+}
+
+// Overrides returns a copy of the current overrides, by flag key
+func (p *OverrideProvider) Overrides() map[string]interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	overrides := make(map[string]interface{}, len(p.overrides))
+	for key, value := range p.overrides {
+		overrides[key] = value
+	}
+	return overrides
+}
+
+// Reset clears all overrides without emitting events, and resets the wrapped provider if it is Resettable
+func (p *OverrideProvider) Reset() {
+	p.mu.Lock()
+	p.overrides = map[string]interface{}{}
+	p.mu.Unlock()
+
+	p.delegatingProvider.Reset()
+}
+
+// BooleanEvaluation returns the override of a boolean flag, or evaluates it with the wrapped provider
+func (p *OverrideProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	if value, detail, ok := override(p, flag, Boolean, defaultValue); ok {
+		return BoolResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+	return p.FeatureProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// StringEvaluation returns the override of a string flag, or evaluates it with the wrapped provider
+func (p *OverrideProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	if value, detail, ok := override(p, flag, String, defaultValue); ok {
+		return StringResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+	return p.FeatureProvider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// FloatEvaluation returns the override of a float flag, or evaluates it with the wrapped provider
+func (p *OverrideProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	if value, detail, ok := override(p, flag, Float, defaultValue); ok {
+		return FloatResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+	return p.FeatureProvider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// IntEvaluation returns the override of an int flag, or evaluates it with the wrapped provider
+func (p *OverrideProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	p.mu.RLock()
+	value, ok := p.overrides[flag]
+	p.mu.RUnlock()
+	if i, isInt := value.(int); ok && isInt {
+		return IntResolutionDetail{Value: int64(i), ProviderResolutionDetail: ProviderResolutionDetail{Reason: OverrideReason}}
+	}
+
+	if value, detail, ok := override(p, flag, Int, defaultValue); ok {
+		return IntResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+	return p.FeatureProvider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// ObjectEvaluation returns the override of an object flag, or evaluates it with the wrapped provider
+func (p *OverrideProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	if value, detail, ok := override(p, flag, Object, defaultValue); ok {
+		return InterfaceResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+	}
+	return p.FeatureProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+// EventChannel returns a channel relaying the events of the wrapped provider, along with the events of override changes
+func (p *OverrideProvider) EventChannel() <-chan Event {
+	handler, ok := p.FeatureProvider.(EventHandler)
+	if !ok {
+		return p.events
+	}
+
+	p.forwardOnce.Do(func() {
+		events := handler.EventChannel()
+		go func() {
+			for {
+				select {
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					select {
+					case p.events <- event:
+					case <-p.done:
+						return
+					}
+				case <-p.done:
+					return
+				}
+			}
+		}()
+	})
+
+	return p.events
+}
+
+// emitsEvents returns true, as override changes are emitted as events
+func (p *OverrideProvider) emitsEvents() bool {
+	return true
+}
+
+// Shutdown stops relaying events and shuts down the wrapped provider
+func (p *OverrideProvider) Shutdown() {
+	p.shutdownOnce.Do(func() {
+		close(p.done)
+	})
+	p.delegatingProvider.Shutdown()
+}
+
+// emitChange emits a PROVIDER_CONFIGURATION_CHANGED event for the flag. The event is sent from its own goroutine, so
+// that changing overrides never blocks, even if no one consumes the events.
+func (p *OverrideProvider) emitChange(flagKey string, message string) {
+	event := Event{
+		ProviderName: p.Metadata().Name,
+		EventType:    ProviderConfigChange,
+		ProviderEventDetails: ProviderEventDetails{
+			Message:           message,
+			FlagChanges:       []string{flagKey},
+			FlagChangeDetails: map[string]FlagChange{flagKey: {Type: FlagModified}},
+		},
+	}
+
+	go func() {
+		select {
+		case p.events <- event:
+		case <-p.done:
+		}
+	}()
+}
+
+// override returns the override of the flag, if any. An override not of the flag type resolves to the default value
+// with a TYPE_MISMATCH error.
+func override[T any](p *OverrideProvider, flag string, flagType Type, defaultValue T) (T, ProviderResolutionDetail, bool) {
+	p.mu.RLock()
+	value, ok := p.overrides[flag]
+	p.mu.RUnlock()
+	if !ok {
+		return defaultValue, ProviderResolutionDetail{}, false
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return defaultValue, ProviderResolutionDetail{
+			ResolutionError: NewTypeMismatchResolutionError(
+				fmt.Sprintf("override of flag %s is a %T, expected %s", flag, value, flagType),
+			),
+			Reason: ErrorReason,
+		}, true
+	}
+	return typed, ProviderResolutionDetail{Reason: OverrideReason}, true
+}
@@ -0,0 +1,119 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOverrideProvider(t *testing.T) {
+	t.Run("overridden flags short-circuit the wrapped provider", func(t *testing.T) {
+		provider := NewOverrideProvider(NoopProvider{})
+		provider.SetOverride("kill-switch", true)
+		provider.SetOverride("retries", 3)
+
+		boolDetail := provider.BooleanEvaluation(context.Background(), "kill-switch", false, FlattenedContext{})
+		if !boolDetail.Value || boolDetail.Reason != OverrideReason {
+			t.Errorf("expected the override, got %+v", boolDetail)
+		}
+
+		intDetail := provider.IntEvaluation(context.Background(), "retries", 1, FlattenedContext{})
+		if intDetail.Value != 3 || intDetail.Reason != OverrideReason {
+			t.Errorf("expected the override, got %+v", intDetail)
+		}
+
+		stringDetail := provider.StringEvaluation(context.Background(), "other", "default", FlattenedContext{})
+		if stringDetail.Value != "default" || stringDetail.Reason != DefaultReason {
+			t.Errorf("expected the wrapped provider result, got %+v", stringDetail)
+		}
+	})
+
+	t.Run("cleared overrides delegate again", func(t *testing.T) {
+		provider := NewOverrideProvider(NoopProvider{})
+		provider.SetOverride("kill-switch", true)
+		provider.ClearOverride("kill-switch")
+
+		detail := provider.BooleanEvaluation(context.Background(), "kill-switch", false, FlattenedContext{})
+		if detail.Value || detail.Reason != DefaultReason {
+			t.Errorf("expected the wrapped provider result, got %+v", detail)
+		}
+		if len(provider.Overrides()) != 0 {
+			t.Errorf("expected no overrides, got %v", provider.Overrides())
+		}
+	})
+
+	t.Run("overrides of another type are a type mismatch", func(t *testing.T) {
+		provider := NewOverrideProvider(NoopProvider{})
+		provider.SetOverride("flag", "on")
+
+		detail := provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+		if detail.Error() == nil || detail.ResolutionDetail().ErrorCode != TypeMismatchCode {
+			t.Errorf("expected a type mismatch, got %+v", detail)
+		}
+	})
+
+	t.Run("override changes emit configuration change events", func(t *testing.T) {
+		provider := NewOverrideProvider(NoopProvider{})
+		defer provider.Shutdown()
+
+		provider.SetOverride("flag", true)
+
+		select {
+		case event := <-provider.EventChannel():
+			if event.EventType != ProviderConfigChange || len(event.FlagChanges) != 1 || event.FlagChanges[0] != "flag" {
+				t.Errorf("expected a configuration change of the flag, got %+v", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a configuration change event")
+		}
+	})
+
+	t.Run("events of the wrapped provider are relayed", func(t *testing.T) {
+		eventing := &ProviderEventing{c: make(chan Event, 1)}
+		provider := NewOverrideProvider(struct {
+			FeatureProvider
+			EventHandler
+		}{NoopProvider{}, eventing})
+		defer provider.Shutdown()
+
+		eventing.Invoke(Event{EventType: ProviderStale})
+
+		select {
+		case event := <-provider.EventChannel():
+			if event.EventType != ProviderStale {
+				t.Errorf("expected the relayed event, got %+v", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the event to be relayed")
+		}
+	})
+
+	t.Run("closed event channel of the wrapped provider", func(t *testing.T) {
+		eventing := &ProviderEventing{c: make(chan Event)}
+		close(eventing.c)
+		provider := NewOverrideProvider(struct {
+			FeatureProvider
+			EventHandler
+		}{NoopProvider{}, eventing})
+		defer provider.Shutdown()
+
+		events := provider.EventChannel()
+		select {
+		case event := <-events:
+			t.Errorf("expected no event to be relayed, got %+v", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("reset clears all overrides", func(t *testing.T) {
+		provider := NewOverrideProvider(NoopProvider{})
+		provider.SetOverride("a", true)
+		provider.SetOverride("b", "value")
+
+		provider.Reset()
+
+		if len(provider.Overrides()) != 0 {
+			t.Errorf("expected no overrides, got %v", provider.Overrides())
+		}
+	})
+}
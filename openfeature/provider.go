@@ -2,7 +2,7 @@ package openfeature
 
 import (
 	"context"
-	"errors"
+	"time"
 )
 
 const (
@@ -22,6 +22,11 @@ const (
 	UnknownReason Reason = "UNKNOWN"
 	// ErrorReason - the resolved value was the result of an error.
 	ErrorReason Reason = "ERROR"
+	// TargetingKeyMissingReason - the default value was returned because the provider required a targeting key and
+	// none was provided in the evaluation context.
+	TargetingKeyMissingReason Reason = "TARGETING_KEY_MISSING"
+	// OverrideReason - the resolved value was forced at runtime, see OverrideProvider.
+	OverrideReason Reason = "OVERRIDE"
 
 	NotReadyState State = "NOT_READY"
 	ReadyState    State = "READY"
@@ -59,6 +64,26 @@ type FeatureProvider interface {
 // State represents the status of the provider
 type State string
 
+// String returns the string representation of the state
+func (s State) String() string {
+	return string(s)
+}
+
+// IsWorseThan reports whether the state is more severe than the other state. From the least to the most severe:
+// READY, STALE, NOT_READY, ERROR, FATAL. Unknown states are as severe as READY.
+func (s State) IsWorseThan(other State) bool {
+	return stateSeverity[s] > stateSeverity[other]
+}
+
+// stateSeverity ranks states for aggregation, the higher the worse
+var stateSeverity = map[State]int{
+	ReadyState:    0,
+	StaleState:    1,
+	NotReadyState: 2,
+	ErrorState:    3,
+	FatalState:    4,
+}
+
 // StateHandler is the contract for initialization & shutdown.
 // FeatureProvider can opt in for this behavior by implementing the interface
 type StateHandler interface {
@@ -66,12 +91,83 @@ type StateHandler interface {
 	Shutdown()
 }
 
+// ContextInitializer is the contract for initialization bound to a context.Context, so that providers performing I/O
+// during initialization can honor cancellation and deadlines. When implemented, the SDK calls InitWithContext
+// instead of StateHandler.Init, with the context given to SetProviderAndWaitContext or SetNamedProviderAndWaitContext,
+// or with context.Background() otherwise.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type ContextInitializer interface {
+	InitWithContext(ctx context.Context, evaluationContext EvaluationContext) error
+}
+
+// FreshnessReporter is the contract for reporting when the data served by the provider was last updated, e.g. the
+// time of its last successful synchronization with its backend. The client reports the age of the data in the flag
+// metadata of each evaluation, under ValueAgeMsFlagMetadataKey, so that callers can apply their own freshness policy
+// when the provider is STALE. A zero time means the update time is unknown.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type FreshnessReporter interface {
+	LastUpdated() time.Time
+}
+
 // Tracker is the contract for tracking
 // FeatureProvider can opt in for this behavior by implementing the interface
 type Tracker interface {
 	Track(ctx context.Context, trackingEventName string, evaluationContext EvaluationContext, details TrackingEventDetails)
 }
 
+// DefaultContextSupplier is the contract for supplying a provider-level evaluation context, e.g. the region or
+// deployment the provider serves, applying to every evaluation of the provider. The client merges it at the lowest
+// precedence, below the API (global) context whatever the merge precedence, so that the application can override any
+// of its attributes. It is not merged into exclusive contexts (see WithExclusiveContext).
+// FeatureProvider can opt in for this behavior by implementing the interface
+type DefaultContextSupplier interface {
+	DefaultContext() EvaluationContext
+}
+
+// ContextEnricher is the contract for enriching the evaluation context with attributes computed by the provider
+// (e.g. geo location derived from an IP address). The client calls it before running the before hooks, so enriched
+// attributes are visible to hooks and to the resolution. Enriched attributes have the lowest precedence: they never
+// override attributes of the API, transaction, client or invocation contexts.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type ContextEnricher interface {
+	EnrichContext(ctx context.Context, flat FlattenedContext) FlattenedContext
+}
+
+// Snapshotter is the contract for pinning the flag configuration of a provider, see Client.Snapshot.
+// Snapshot returns a FeatureProvider resolving flags from the configuration as it was when Snapshot was called,
+// unaffected by later configuration changes. A nil provider without error means that the configuration cannot be
+// pinned, and evaluations behave normally.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type Snapshotter interface {
+	Snapshot(ctx context.Context) (FeatureProvider, error)
+}
+
+// DefaultOverrider is the contract for providers prescribing the default value of flags, e.g. flag management
+// systems defining the code default server-side so that all services agree on it.
+// When DefaultFor returns true, the returned value replaces the default value given by the caller of the evaluation,
+// including the value returned when the evaluation fails. A returned value not of the type of the flag (bool, string,
+// float64, int64, or any value for object flags) is ignored.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type DefaultOverrider interface {
+	DefaultFor(flagKey string, flagType Type) (interface{}, bool)
+}
+
+// FlagSchema is the contract for advertising the type of the flags of a provider, e.g. for validation tooling.
+// FlagSchema returns the declared type of each flag, keyed by flag key. See WithFlagSchemaValidation to have the
+// client reject evaluations not matching the declared type. A nil schema means that the provider does not declare its
+// flags.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type FlagSchema interface {
+	FlagSchema() map[string]Type
+}
+
+// Resettable is the contract for returning a stateful provider (e.g. holding caches or circuit breakers) to its
+// initial state, so that test cases do not contaminate each other. Testing helpers call Reset when cleaning up.
+// FeatureProvider can opt in for this behavior by implementing the interface
+type Resettable interface {
+	Reset()
+}
+
 // NoopStateHandler is a noop StateHandler implementation
 // Status always set to ReadyState to comply with specification
 type NoopStateHandler struct {
@@ -100,12 +196,47 @@ type EventHandler interface {
 // EventType emitted by a provider implementation
 type EventType string
 
+// String returns the string representation of the event type
+func (t EventType) String() string {
+	return string(t)
+}
+
+// AllEventTypes returns all event types known to the SDK
+func AllEventTypes() []EventType {
+	return []EventType{ProviderReady, ProviderConfigChange, ProviderStale, ProviderError}
+}
+
 // ProviderEventDetails is the event payload emitted by FeatureProvider
 type ProviderEventDetails struct {
-	Message       string
-	FlagChanges   []string
-	EventMetadata map[string]interface{}
-	ErrorCode     ErrorCode
+	Message     string
+	FlagChanges []string
+	// FlagChangeDetails optionally describes how each changed flag changed, keyed by flag key. Providers knowing
+	// exactly what changed can set it along with FlagChanges, so that handlers can react precisely (e.g. invalidate
+	// only the affected cache entries).
+	FlagChangeDetails map[string]FlagChange
+	EventMetadata     map[string]interface{}
+	ErrorCode         ErrorCode
+}
+
+// FlagChangeType is the kind of change of a flag
+type FlagChangeType string
+
+const (
+	// FlagAdded - the flag did not exist before the change
+	FlagAdded FlagChangeType = "ADDED"
+	// FlagRemoved - the flag no longer exists after the change
+	FlagRemoved FlagChangeType = "REMOVED"
+	// FlagModified - the flag exists before and after the change, with a different configuration
+	FlagModified FlagChangeType = "MODIFIED"
+)
+
+// FlagChange describes the change of a single flag in a PROVIDER_CONFIGURATION_CHANGED event
+type FlagChange struct {
+	Type FlagChangeType
+	// OldVariant is the variant before the change, if known. Empty for added flags.
+	OldVariant string
+	// NewVariant is the variant after the change, if known. Empty for removed flags.
+	NewVariant string
 }
 
 // Event is an event emitted by a FeatureProvider.
@@ -152,6 +283,7 @@ func (p ProviderResolutionDetail) ResolutionDetail() ResolutionDetail {
 		Reason:       p.Reason,
 		ErrorCode:    p.ResolutionError.code,
 		ErrorMessage: p.ResolutionError.message,
+		ErrorCause:   p.ResolutionError.cause,
 		FlagMetadata: metadata,
 	}
 }
@@ -160,7 +292,7 @@ func (p ProviderResolutionDetail) Error() error {
 	if p.ResolutionError.code == "" {
 		return nil
 	}
-	return errors.New(p.ResolutionError.Error())
+	return p.ResolutionError
 }
 
 // BoolResolutionDetail provides a resolution detail with boolean type
@@ -196,6 +328,9 @@ type InterfaceResolutionDetail struct {
 // Metadata provides provider name
 type Metadata struct {
 	Name string
+	// Version optionally identifies the version of the provider implementation, e.g. to tell two versions of a
+	// provider apart, see NewCanaryProvider
+	Version string
 }
 
 // TrackingEventDetails provides a tracking details with float64 value
@@ -0,0 +1,47 @@
+package openfeature
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature/internal"
+)
+
+// WithProviderOption passes a provider-specific hint to the provider for this evaluation, e.g. to skip a cache.
+//
+// Provider options do not belong to the evaluation context: they travel with the context.Context given to the
+// provider, which retrieves them with ProviderOption. Providers not knowing an option ignore it. Keys should be
+// namespaced by provider (e.g. "myprovider.skipCache") to avoid collisions.
+func WithProviderOption(key string, value interface{}) Option {
+	return func(options *EvaluationOptions) {
+		if options.providerOptions == nil {
+			options.providerOptions = map[string]interface{}{}
+		}
+		options.providerOptions[key] = value
+	}
+}
+
+// ProviderOption returns the value of the provider option with the given key carried by ctx, if any.
+// Meant to be used by providers, see WithProviderOption.
+func ProviderOption(ctx context.Context, key string) (interface{}, bool) {
+	options, ok := ctx.Value(internal.ProviderOptions).(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	value, ok := options[key]
+	return value, ok
+}
+
+// withProviderOptions returns a copy of ctx carrying the given provider options, merged over the ones ctx already
+// carries
+func withProviderOptions(ctx context.Context, providerOptions map[string]interface{}) context.Context {
+	merged := map[string]interface{}{}
+	if existing, ok := ctx.Value(internal.ProviderOptions).(map[string]interface{}); ok {
+		for key, value := range existing {
+			merged[key] = value
+		}
+	}
+	for key, value := range providerOptions {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, internal.ProviderOptions, merged)
+}
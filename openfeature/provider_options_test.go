@@ -0,0 +1,60 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+)
+
+// optionAwareProvider resolves boolean flags to the value of the "test.value" provider option, if set
+type optionAwareProvider struct {
+	NoopProvider
+}
+
+func (p optionAwareProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	if value, ok := ProviderOption(ctx, "test.value"); ok {
+		return BoolResolutionDetail{
+			Value:                    value.(bool),
+			ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason},
+		}
+	}
+	return p.NoopProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+}
+
+func TestWithProviderOption(t *testing.T) {
+	executor := newEventExecutor()
+	client := newClient(t.Name(), newEvaluationAPI(executor), executor)
+	if err := client.api.SetProviderAndWait(optionAwareProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	value, err := client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}, WithProviderOption("test.value", true))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !value {
+		t.Error("expected the provider to receive the provider option")
+	}
+
+	value, err = client.BooleanValue(context.Background(), "flag", false, EvaluationContext{}, WithProviderOption("other.value", true))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if value {
+		t.Error("expected unknown provider options to be ignored")
+	}
+}
+
+func TestProviderOptionMerging(t *testing.T) {
+	ctx := withProviderOptions(context.Background(), map[string]interface{}{"a": 1, "b": 1})
+	ctx = withProviderOptions(ctx, map[string]interface{}{"b": 2})
+
+	if value, ok := ProviderOption(ctx, "a"); !ok || value != 1 {
+		t.Errorf("expected existing options to be kept, got %v", value)
+	}
+	if value, ok := ProviderOption(ctx, "b"); !ok || value != 2 {
+		t.Errorf("expected new options to take precedence, got %v", value)
+	}
+	if _, ok := ProviderOption(context.Background(), "a"); ok {
+		t.Error("expected no option for a context without provider options")
+	}
+}
@@ -2,6 +2,7 @@ package openfeature
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -118,3 +119,46 @@ func TestTrackingEventDetails_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestAllEventTypes(t *testing.T) {
+	eventTypes := AllEventTypes()
+
+	for _, eventType := range eventTypes {
+		if _, ok := statesMap[eventType]; !ok {
+			t.Errorf("event type %s has no state mapping", eventType)
+		}
+		if eventType.String() != string(eventType) {
+			t.Errorf("expected %s, got %s", string(eventType), eventType.String())
+		}
+	}
+
+	if len(eventTypes) != len(statesMap) {
+		t.Errorf("expected %d event types, got %d", len(statesMap), len(eventTypes))
+	}
+}
+
+func TestStateIsWorseThan(t *testing.T) {
+	ordered := []State{ReadyState, StaleState, NotReadyState, ErrorState, FatalState}
+
+	for i, state := range ordered {
+		if state.String() != string(state) {
+			t.Errorf("expected %s, got %s", string(state), state.String())
+		}
+		for j, other := range ordered {
+			if got, want := state.IsWorseThan(other), i > j; got != want {
+				t.Errorf("expected %s.IsWorseThan(%s) to be %t, got %t", state, other, want, got)
+			}
+		}
+	}
+}
+
+func TestUnsupportedOperationError(t *testing.T) {
+	err := NewUnsupportedOperationError("ListFlags")
+
+	if !errors.Is(err, ErrOperationNotSupported) {
+		t.Errorf("expected the error to wrap ErrOperationNotSupported, got %v", err)
+	}
+	if want := "ListFlags: operation not supported by the provider"; err.Error() != want {
+		t.Errorf("expected message %q, got %q", want, err.Error())
+	}
+}
@@ -0,0 +1,54 @@
+package openfeature
+
+import "strings"
+
+const (
+	// ReasonCodeFlagMetadataKey is the flag metadata key holding the code of a structured reason, see ReasonDetail.
+	ReasonCodeFlagMetadataKey = "reasonCode"
+	// ReasonFieldFlagMetadataPrefix is the prefix of the flag metadata keys holding the fields of a structured reason,
+	// e.g. "reason.rule" for the field "rule".
+	ReasonFieldFlagMetadataPrefix = "reason."
+)
+
+// ReasonDetail is a structured reason, conveying why a value was resolved in more detail than the Reason string, e.g.
+// the targeting rule which matched. Providers attach it to the flag metadata with WithStructuredReason, while keeping
+// the Reason of the resolution for compatibility, and callers read it back with StructuredReason.
+type ReasonDetail struct {
+	// Code identifies the kind of reason, e.g. "RULE_MATCH"
+	Code string
+	// Fields holds the details of the reason, with values of type boolean, string, int64 or float64
+	Fields map[string]interface{}
+}
+
+// WithStructuredReason returns a copy of the flag metadata holding the given structured reason. Any structured reason
+// already held by the metadata is replaced.
+func WithStructuredReason(md FlagMetadata, reason ReasonDetail) FlagMetadata {
+	metadata := make(FlagMetadata, len(md)+len(reason.Fields)+1)
+	for key, value := range md {
+		if key == ReasonCodeFlagMetadataKey || strings.HasPrefix(key, ReasonFieldFlagMetadataPrefix) {
+			continue
+		}
+		metadata[key] = value
+	}
+	metadata[ReasonCodeFlagMetadataKey] = reason.Code
+	for key, value := range reason.Fields {
+		metadata[ReasonFieldFlagMetadataPrefix+key] = value
+	}
+	return metadata
+}
+
+// StructuredReason returns the structured reason held by the flag metadata, if any, see WithStructuredReason.
+func StructuredReason(md FlagMetadata) (ReasonDetail, bool) {
+	code, ok := md[ReasonCodeFlagMetadataKey].(string)
+	if !ok {
+		return ReasonDetail{}, false
+	}
+
+	reason := ReasonDetail{Code: code, Fields: map[string]interface{}{}}
+	for key, value := range md {
+		if field, ok := strings.CutPrefix(key, ReasonFieldFlagMetadataPrefix); ok {
+			reason.Fields[field] = value
+		}
+	}
+	return reason, true
+}
@@ -0,0 +1,51 @@
+package openfeature
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestStructuredReason(t *testing.T) {
+	reason := ReasonDetail{Code: "RULE_MATCH", Fields: map[string]interface{}{"rule": "R3", "priority": int64(2)}}
+	metadata := WithStructuredReason(FlagMetadata{"owner": "checkout", "reason.stale": true}, reason)
+
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	provider := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+		if flag == "plain" {
+			return true, ProviderResolutionDetail{Reason: TargetingMatchReason}
+		}
+		return true, ProviderResolutionDetail{Reason: TargetingMatchReason, FlagMetadata: metadata}
+	})
+	if err := api.SetProviderAndWait(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	client := newClient(t.Name(), api, executor)
+
+	details, err := client.BooleanValueDetails(context.Background(), "structured", false, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if details.Reason != TargetingMatchReason {
+		t.Errorf("expected reason %s, got %s", TargetingMatchReason, details.Reason)
+	}
+	if owner, _ := details.FlagMetadata.GetString("owner"); owner != "checkout" {
+		t.Errorf("expected unrelated metadata to be kept, got %v", details.FlagMetadata)
+	}
+	got, ok := StructuredReason(details.FlagMetadata)
+	if !ok {
+		t.Fatal("expected a structured reason")
+	}
+	if !reflect.DeepEqual(got, reason) {
+		t.Errorf("expected structured reason %v, got %v", reason, got)
+	}
+
+	details, err = client.BooleanValueDetails(context.Background(), "plain", false, EvaluationContext{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := StructuredReason(details.FlagMetadata); ok {
+		t.Error("expected no structured reason")
+	}
+}
@@ -0,0 +1,26 @@
+package openfeature
+
+// RegistrationOption applies a change to the registration of a provider, see SetProviderWithOptions
+type RegistrationOption func(*registrationOptions)
+
+type registrationOptions struct {
+	suppressInitialReady bool
+	// once rejects the registration if a default provider was set already
+	once bool
+}
+
+// WithSuppressInitialReady holds the PROVIDER_READY event emitted once the provider initialization succeeded, until
+// MarkReady is called for its domain. Until then, the provider stays in the NOT_READY state and READY handlers are not
+// run, which gives control over startup event timing, e.g. to signal readiness of several providers in a specific
+// order. Initialization errors are emitted as usual. Events emitted by the provider itself are not held.
+func WithSuppressInitialReady() RegistrationOption {
+	return func(options *registrationOptions) {
+		options.suppressInitialReady = true
+	}
+}
+
+// heldReady is a PROVIDER_READY event held by WithSuppressInitialReady
+type heldReady struct {
+	event    Event
+	provider FeatureProvider
+}
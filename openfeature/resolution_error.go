@@ -32,12 +32,26 @@ type ResolutionError struct {
 	// this effectively emulates an enum
 	code    ErrorCode
 	message string
+	cause   error
 }
 
 func (r ResolutionError) Error() string {
 	return fmt.Sprintf("%s: %s", r.code, r.message)
 }
 
+// Unwrap returns the underlying error which caused the resolution error, if any
+func (r ResolutionError) Unwrap() error {
+	return r.cause
+}
+
+// WithCause returns a copy of the resolution error carrying the underlying error which caused it.
+// The cause is surfaced to callers as the ErrorCause of the evaluation details, so that they can inspect rich
+// provider errors (e.g. wrapped HTTP or gRPC errors) with errors.As.
+func (r ResolutionError) WithCause(err error) ResolutionError {
+	r.cause = err
+	return r
+}
+
 // NewProviderNotReadyResolutionError constructs a resolution error with code PROVIDER_NOT_READY
 //
 // Explanation - The value was resolved before the provider was ready.
@@ -124,4 +138,23 @@ var (
 	ProviderNotReadyError = errors.New("provider not yet initialized")
 	// ProviderFatalError signifies that an operation failed because the provider is in a FATAL state.
 	ProviderFatalError = errors.New("provider is in an irrecoverable error state")
+	// ErrOperationNotSupported signifies that an operation failed because the provider does not implement the optional
+	// capability it requires. Errors created with NewUnsupportedOperationError wrap it, so that callers probing
+	// provider capabilities can check for it with errors.Is.
+	ErrOperationNotSupported = errors.New("operation not supported by the provider")
+	// ErrProviderRequired signifies that an evaluation failed closed because it required a READY provider, see
+	// WithRequireProvider. Such errors also wrap ProviderNotReadyError or ProviderFatalError.
+	ErrProviderRequired = errors.New("evaluation requires a ready provider")
 )
+
+// ErrorCodeOf returns the error code of an evaluation error, e.g. as received by the error stage of hooks: the code of
+// the ResolutionError it wraps, PROVIDER_NOT_READY or PROVIDER_FATAL for the provider state errors, and GENERAL
+// otherwise.
+func ErrorCodeOf(err error) ErrorCode {
+	return errorCode(err)
+}
+
+// NewUnsupportedOperationError returns an error wrapping ErrOperationNotSupported, naming the unsupported operation
+func NewUnsupportedOperationError(op string) error {
+	return fmt.Errorf("%s: %w", op, ErrOperationNotSupported)
+}
@@ -0,0 +1,162 @@
+package openfeature
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+)
+
+// maxPendingShadowEvaluations bounds the shadow evaluations in flight, beyond which comparisons are skipped
+const maxPendingShadowEvaluations = 100
+
+// ShadowProvider is a FeatureProvider decorator comparing the results of a candidate provider with the ones of the
+// wrapped primary provider, e.g. to validate a new provider in production before cutting over. Results are always
+// the ones of the primary provider. Lifecycle, eventing and tracking are delegated to the primary provider.
+type ShadowProvider struct {
+	delegatingProvider
+	shadow       FeatureProvider
+	onDivergence func(flagKey string, primaryVal, shadowVal interface{})
+	pending      chan struct{}
+}
+
+// ProviderDivergence describes a flag resolved to different values by two versions of a provider, see
+// NewCanaryProvider
+type ProviderDivergence struct {
+	FlagKey      string
+	Primary      Metadata
+	PrimaryValue interface{}
+	Canary       Metadata
+	CanaryValue  interface{}
+}
+
+// NewShadowProvider wraps the primary provider so that each evaluation is also run against the shadow provider.
+// Shadow evaluations run asynchronously, so they never delay nor affect the results returned by the primary
+// provider. onDivergence is called, from the goroutine of the shadow evaluation, when the value of the shadow provider
+// differs from the value of the primary provider. Panics of the shadow provider and of onDivergence are recovered and
+// logged. At most 100 shadow evaluations run at once: comparisons are skipped beyond, so that a
+// slow shadow provider cannot pile up goroutines.
+//
+// The shadow provider is initialized and shut down along with the primary provider. Its initialization errors are
+// ignored, and its events are not relayed.
+func NewShadowProvider(
+	primary, shadow FeatureProvider, onDivergence func(flagKey string, primaryVal, shadowVal interface{}),
+) *ShadowProvider {
+	return &ShadowProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: primary},
+		shadow:             shadow,
+		onDivergence:       onDivergence,
+		pending:            make(chan struct{}, maxPendingShadowEvaluations),
+	}
+}
+
+// NewCanaryProvider wraps the primary provider so that each evaluation is also run against the canary provider, e.g. a
+// new version of the same provider implementation being rolled out. It behaves as a ShadowProvider, and reports
+// divergences along with the metadata of both providers, so that they can be attributed to a provider version.
+func NewCanaryProvider(primary, canary FeatureProvider, onDivergence func(divergence ProviderDivergence)) *ShadowProvider {
+	if onDivergence == nil {
+		return NewShadowProvider(primary, canary, nil)
+	}
+	return NewShadowProvider(primary, canary, func(flagKey string, primaryVal, canaryVal interface{}) {
+		onDivergence(ProviderDivergence{
+			FlagKey:      flagKey,
+			Primary:      primary.Metadata(),
+			PrimaryValue: primaryVal,
+			Canary:       canary.Metadata(),
+			CanaryValue:  canaryVal,
+		})
+	})
+}
+
+// Init initializes the primary and the shadow providers
+func (p *ShadowProvider) Init(evaluationContext EvaluationContext) error {
+	return p.InitWithContext(context.Background(), evaluationContext)
+}
+
+// InitWithContext initializes the primary and the shadow providers, with the context if they are ContextInitializer
+func (p *ShadowProvider) InitWithContext(ctx context.Context, evaluationContext EvaluationContext) error {
+	_ = delegatingProvider{FeatureProvider: p.shadow}.InitWithContext(ctx, evaluationContext)
+	return p.delegatingProvider.InitWithContext(ctx, evaluationContext)
+}
+
+// requiresInitialization returns whether the primary or the shadow provider has an initialization to run
+func (p *ShadowProvider) requiresInitialization() bool {
+	return requiresInitialization(p.FeatureProvider) || requiresInitialization(p.shadow)
+}
+
+// Shutdown shuts the primary and the shadow providers down
+func (p *ShadowProvider) Shutdown() {
+	p.delegatingProvider.Shutdown()
+	if handler, ok := p.shadow.(StateHandler); ok {
+		handler.Shutdown()
+	}
+}
+
+// BooleanEvaluation returns the evaluation of the primary provider, and compares it with the shadow provider
+func (p *ShadowProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	res := p.FeatureProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.compare(flag, res.Value, func() interface{} {
+		return p.shadow.BooleanEvaluation(context.WithoutCancel(ctx), flag, defaultValue, evalCtx).Value
+	})
+	return res
+}
+
+// StringEvaluation returns the evaluation of the primary provider, and compares it with the shadow provider
+func (p *ShadowProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	res := p.FeatureProvider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.compare(flag, res.Value, func() interface{} {
+		return p.shadow.StringEvaluation(context.WithoutCancel(ctx), flag, defaultValue, evalCtx).Value
+	})
+	return res
+}
+
+// FloatEvaluation returns the evaluation of the primary provider, and compares it with the shadow provider
+func (p *ShadowProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	res := p.FeatureProvider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.compare(flag, res.Value, func() interface{} {
+		return p.shadow.FloatEvaluation(context.WithoutCancel(ctx), flag, defaultValue, evalCtx).Value
+	})
+	return res
+}
+
+// IntEvaluation returns the evaluation of the primary provider, and compares it with the shadow provider
+func (p *ShadowProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	res := p.FeatureProvider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.compare(flag, res.Value, func() interface{} {
+		return p.shadow.IntEvaluation(context.WithoutCancel(ctx), flag, defaultValue, evalCtx).Value
+	})
+	return res
+}
+
+// ObjectEvaluation returns the evaluation of the primary provider, and compares it with the shadow provider
+func (p *ShadowProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	res := p.FeatureProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+	p.compare(flag, res.Value, func() interface{} {
+		return p.shadow.ObjectEvaluation(context.WithoutCancel(ctx), flag, defaultValue, evalCtx).Value
+	})
+	return res
+}
+
+// compare runs the shadow evaluation in its own goroutine, and reports a divergence from the primary value.
+// The shadow evaluation is detached from the cancellation of the evaluation context, as it outlives the evaluation.
+func (p *ShadowProvider) compare(flag string, primaryVal interface{}, evaluateShadow func() interface{}) {
+	select {
+	case p.pending <- struct{}{}:
+	default:
+		slog.Debug("skipping shadow evaluation, too many in flight", "flag", flag)
+		return
+	}
+
+	go func() {
+		defer func() {
+			<-p.pending
+			if r := recover(); r != nil {
+				slog.Error("recovered from a panic in shadow evaluation", "flag", flag, "panic", r)
+			}
+		}()
+
+		shadowVal := evaluateShadow()
+		if p.onDivergence != nil && !reflect.DeepEqual(primaryVal, shadowVal) {
+			p.onDivergence(flag, primaryVal, shadowVal)
+		}
+	}()
+}
@@ -0,0 +1,205 @@
+package openfeature
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type divergence struct {
+	flagKey    string
+	primaryVal interface{}
+	shadowVal  interface{}
+}
+
+func TestShadowProvider(t *testing.T) {
+	t.Run("divergent shadow is reported without affecting the result", func(t *testing.T) {
+		divergences := make(chan divergence, 1)
+		shadow := slowProvider{delay: 50 * time.Millisecond, canceled: make(chan struct{})}
+		provider := NewShadowProvider(NoopProvider{}, shadow, func(flagKey string, primaryVal, shadowVal interface{}) {
+			divergences <- divergence{flagKey, primaryVal, shadowVal}
+		})
+
+		start := time.Now()
+		detail := provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+		if detail.Value || detail.Reason != DefaultReason {
+			t.Errorf("expected the primary result, got %+v", detail)
+		}
+		if elapsed := time.Since(start); elapsed >= shadow.delay {
+			t.Errorf("expected the shadow evaluation not to delay the result, took %s", elapsed)
+		}
+
+		select {
+		case got := <-divergences:
+			want := divergence{"flag", false, true}
+			if got != want {
+				t.Errorf("expected divergence %+v, got %+v", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a divergence to be reported")
+		}
+	})
+
+	t.Run("matching shadow is not reported", func(t *testing.T) {
+		divergences := make(chan divergence, 1)
+		provider := NewShadowProvider(NoopProvider{}, NoopProvider{}, func(flagKey string, primaryVal, shadowVal interface{}) {
+			divergences <- divergence{flagKey, primaryVal, shadowVal}
+		})
+
+		provider.ObjectEvaluation(context.Background(), "flag", map[string]interface{}{"key": "value"}, FlattenedContext{})
+
+		select {
+		case got := <-divergences:
+			t.Errorf("expected no divergence, got %+v", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("panics are recovered", func(t *testing.T) {
+		divergences := make(chan divergence, 1)
+		shadow := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+			if flag == "panic" {
+				panic("shadow failure")
+			}
+			return true, ProviderResolutionDetail{Reason: StaticReason}
+		})
+		provider := NewShadowProvider(NoopProvider{}, shadow, func(flagKey string, primaryVal, shadowVal interface{}) {
+			divergences <- divergence{flagKey, primaryVal, shadowVal}
+			if flagKey == "panic-on-divergence" {
+				panic("callback failure")
+			}
+		})
+
+		provider.BooleanEvaluation(context.Background(), "panic", false, FlattenedContext{})
+		provider.BooleanEvaluation(context.Background(), "panic-on-divergence", false, FlattenedContext{})
+		provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+
+		got := map[string]bool{}
+		for i := 0; i < 2; i++ {
+			select {
+			case d := <-divergences:
+				got[d.flagKey] = true
+			case <-time.After(time.Second):
+				t.Fatalf("expected divergences to be reported, got %v", got)
+			}
+		}
+		if !got["panic-on-divergence"] || !got["flag"] {
+			t.Errorf("expected divergences after recovered panics, got %v", got)
+		}
+	})
+
+	t.Run("pending shadow evaluations are bounded", func(t *testing.T) {
+		release := make(chan struct{})
+		var started atomic.Int32
+		shadow := NewFuncProvider(func(ctx context.Context, flag string, flagType Type, defaultValue interface{}, evalCtx FlattenedContext) (interface{}, ProviderResolutionDetail) {
+			started.Add(1)
+			<-release
+			return defaultValue, ProviderResolutionDetail{Reason: DefaultReason}
+		})
+		provider := NewShadowProvider(NoopProvider{}, shadow, nil)
+
+		for i := 0; i < 2*maxPendingShadowEvaluations; i++ {
+			provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+		}
+		eventually(t, func() bool {
+			return started.Load() == maxPendingShadowEvaluations
+		}, time.Second, 10*time.Millisecond, "shadow evaluations not started")
+		time.Sleep(50 * time.Millisecond)
+		if got := started.Load(); got != maxPendingShadowEvaluations {
+			t.Errorf("expected %d shadow evaluations in flight, got %d", maxPendingShadowEvaluations, got)
+		}
+
+		close(release)
+		eventually(t, func() bool {
+			return len(provider.pending) == 0
+		}, time.Second, 10*time.Millisecond, "shadow evaluations not completed")
+	})
+
+	t.Run("shadow follows the lifecycle of the primary", func(t *testing.T) {
+		var shadowInit, shadowShutdown bool
+		shadow := struct {
+			FeatureProvider
+			*stateHandlerForTests
+		}{
+			NoopProvider{},
+			&stateHandlerForTests{
+				initF: func(e EvaluationContext) error {
+					shadowInit = true
+					return nil
+				},
+				shutdownF: func() {
+					shadowShutdown = true
+				},
+			},
+		}
+		provider := NewShadowProvider(NoopProvider{}, shadow, nil)
+
+		if err := provider.Init(EvaluationContext{}); err != nil {
+			t.Fatalf("unexpected init error %v", err)
+		}
+		provider.Shutdown()
+
+		if !shadowInit || !shadowShutdown {
+			t.Errorf("expected the shadow to be initialized and shut down, got %t and %t", shadowInit, shadowShutdown)
+		}
+	})
+}
+
+// versionedProvider is a NoopProvider reporting a version
+type versionedProvider struct {
+	NoopProvider
+	version string
+}
+
+func (p versionedProvider) Metadata() Metadata {
+	return Metadata{Name: "versioned", Version: p.version}
+}
+
+func (p versionedProvider) StringEvaluation(_ context.Context, _ string, _ string, _ FlattenedContext) StringResolutionDetail {
+	return StringResolutionDetail{
+		Value:                    p.version,
+		ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason},
+	}
+}
+
+func TestCanaryProvider(t *testing.T) {
+	divergences := make(chan ProviderDivergence, 1)
+	provider := NewCanaryProvider(versionedProvider{version: "v1"}, versionedProvider{version: "v2"}, func(divergence ProviderDivergence) {
+		divergences <- divergence
+	})
+
+	if detail := provider.StringEvaluation(context.Background(), "flag", "", FlattenedContext{}); detail.Value != "v1" {
+		t.Errorf("expected the primary result, got %+v", detail)
+	}
+
+	select {
+	case got := <-divergences:
+		want := ProviderDivergence{
+			FlagKey:      "flag",
+			Primary:      Metadata{Name: "versioned", Version: "v1"},
+			PrimaryValue: "v1",
+			Canary:       Metadata{Name: "versioned", Version: "v2"},
+			CanaryValue:  "v2",
+		}
+		if got != want {
+			t.Errorf("expected divergence %+v, got %+v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a divergence to be reported")
+	}
+}
+
+func TestCanaryProviderWithoutCallback(t *testing.T) {
+	provider := NewCanaryProvider(versionedProvider{version: "v1"}, versionedProvider{version: "v2"}, nil)
+	if provider.onDivergence != nil {
+		t.Fatal("expected no divergence callback")
+	}
+
+	if detail := provider.StringEvaluation(context.Background(), "flag", "", FlattenedContext{}); detail.Value != "v1" {
+		t.Errorf("expected the primary result, got %+v", detail)
+	}
+	eventually(t, func() bool {
+		return len(provider.pending) == 0
+	}, time.Second, 10*time.Millisecond, "shadow evaluation not completed")
+}
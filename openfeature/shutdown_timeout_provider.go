@@ -0,0 +1,40 @@
+package openfeature
+
+import (
+	"log/slog"
+	"time"
+)
+
+// shutdownTimeoutProvider is a FeatureProvider decorator bounding the shutdown of the wrapped provider.
+// Everything else is delegated to the wrapped provider.
+type shutdownTimeoutProvider struct {
+	delegatingProvider
+	timeout time.Duration
+}
+
+func newShutdownTimeoutProvider(delegate FeatureProvider, timeout time.Duration) *shutdownTimeoutProvider {
+	return &shutdownTimeoutProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: delegate},
+		timeout:            timeout,
+	}
+}
+
+// Shutdown shuts down the wrapped provider, waiting for it at most the timeout. A shutdown exceeding the timeout
+// is abandoned and keeps running in the background.
+func (p *shutdownTimeoutProvider) Shutdown() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.delegatingProvider.Shutdown()
+	}()
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		slog.Warn("provider shutdown exceeded its timeout",
+			"provider", p.Metadata().Name, "timeout", p.timeout)
+	}
+}
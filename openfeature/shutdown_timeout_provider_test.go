@@ -0,0 +1,89 @@
+package openfeature
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetProviderWithShutdownTimeout(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+
+	release := make(chan struct{})
+	defer close(release)
+	slowProvider := struct {
+		FeatureProvider
+		StateHandler
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{
+			shutdownF: func() {
+				<-release
+			},
+		},
+	}
+
+	var fastShutdown atomic.Bool
+	fastProvider := struct {
+		FeatureProvider
+		StateHandler
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{
+			shutdownF: func() {
+				fastShutdown.Store(true)
+			},
+		},
+	}
+
+	if err := api.SetProviderWithShutdownTimeout(slowProvider, 50*time.Millisecond); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	if err := api.SetNamedProvider(t.Name(), fastProvider, false); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	start := time.Now()
+	api.Shutdown()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected shutdown to be bounded by the provider timeout, took %s", elapsed)
+	}
+	if !fastShutdown.Load() {
+		t.Error("expected the named provider to be shut down")
+	}
+}
+
+func TestShutdownSharedProvider(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+
+	var shutdowns atomic.Int32
+	provider := &struct {
+		FeatureProvider
+		StateHandler
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{
+			shutdownF: func() {
+				shutdowns.Add(1)
+			},
+		},
+	}
+
+	if err := api.SetProvider(provider); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	for _, domain := range []string{"a", "b"} {
+		if err := api.SetNamedProvider(domain, provider, false); err != nil {
+			t.Fatalf("error setting up provider %v", err)
+		}
+	}
+
+	api.Shutdown()
+
+	if got := shutdowns.Load(); got != 1 {
+		t.Errorf("expected the shared provider to be shut down once, got %d shutdowns", got)
+	}
+}
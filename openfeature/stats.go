@@ -0,0 +1,43 @@
+package openfeature
+
+import "sync/atomic"
+
+// Stats holds the evaluation counters of a provider, since it was set, see ProviderStats
+type Stats struct {
+	// Total is the number of evaluations
+	Total uint64
+	// Errors is the number of evaluations which failed, whatever the reason
+	Errors uint64
+	// NotFound is the number of evaluations which failed because the flag was not found. Those are also counted as
+	// Errors.
+	NotFound uint64
+}
+
+// providerStats holds the evaluation counters of a provider binding. Counters are updated atomically, as evaluations
+// run in parallel.
+type providerStats struct {
+	total    atomic.Uint64
+	errors   atomic.Uint64
+	notFound atomic.Uint64
+}
+
+// record counts an evaluation with its resulting error, if any
+func (s *providerStats) record(err error) {
+	s.total.Add(1)
+	if err == nil {
+		return
+	}
+	s.errors.Add(1)
+	if errorCode(err) == FlagNotFoundCode {
+		s.notFound.Add(1)
+	}
+}
+
+// snapshot returns the current counters
+func (s *providerStats) snapshot() Stats {
+	return Stats{
+		Total:    s.total.Load(),
+		Errors:   s.errors.Load(),
+		NotFound: s.notFound.Load(),
+	}
+}
@@ -0,0 +1,66 @@
+package openfeature
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// notFoundProvider is a provider which does not know any boolean flag
+type notFoundProvider struct {
+	NoopProvider
+}
+
+func (p notFoundProvider) BooleanEvaluation(_ context.Context, _ string, defaultValue bool, _ FlattenedContext) BoolResolutionDetail {
+	return BoolResolutionDetail{
+		Value: defaultValue,
+		ProviderResolutionDetail: ProviderResolutionDetail{
+			ResolutionError: NewFlagNotFoundResolutionError("not found"),
+			Reason:          ErrorReason,
+		},
+	}
+}
+
+func TestProviderStats(t *testing.T) {
+	executor := newEventExecutor()
+	api := newEvaluationAPI(executor)
+	if err := api.SetNamedProviderAndWaitContext(context.Background(), "named", notFoundProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+
+	named := newClient("named", api, executor)
+	unbound := newClient("unbound", api, executor)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = named.BooleanValue(context.Background(), "flag", false, EvaluationContext{})
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = unbound.StringValue(context.Background(), "flag", "", EvaluationContext{})
+		}()
+	}
+	wg.Wait()
+	_, _ = named.StringValue(context.Background(), "flag", "", EvaluationContext{})
+
+	if stats, want := api.ProviderStats("named"), (Stats{Total: 11, Errors: 10, NotFound: 10}); stats != want {
+		t.Errorf("expected named provider stats %+v, got %+v", want, stats)
+	}
+	// the unbound domain uses the default provider
+	if stats, want := api.ProviderStats(defaultDomain), (Stats{Total: 10}); stats != want {
+		t.Errorf("expected default provider stats %+v, got %+v", want, stats)
+	}
+	if stats := api.ProviderStats("unbound"); stats != api.ProviderStats(defaultDomain) {
+		t.Errorf("expected the unbound domain to report the default provider stats, got %+v", stats)
+	}
+
+	if err := api.SetNamedProviderAndWaitContext(context.Background(), "named", NoopProvider{}); err != nil {
+		t.Fatalf("error setting up provider %v", err)
+	}
+	if stats := api.ProviderStats("named"); stats != (Stats{}) {
+		t.Errorf("expected stats to be reset on provider swap, got %+v", stats)
+	}
+}
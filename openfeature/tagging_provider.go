@@ -0,0 +1,85 @@
+package openfeature
+
+import "context"
+
+// taggingProvider is a FeatureProvider decorator attaching operational metadata (region, team, environment, ...) to
+// the flag metadata of every resolution of the wrapped provider. The event executor tags the events of the provider,
+// including the initialization events emitted by the SDK, see tagEvent. The event executor and the shutdown of
+// replaced providers see through the decorator, so that the wrapped provider can also be bound to other domains.
+// Metadata set by the wrapped provider takes precedence over the attached metadata.
+type taggingProvider struct {
+	delegatingProvider
+	metadata map[string]interface{}
+}
+
+func newTaggingProvider(delegate FeatureProvider, metadata map[string]interface{}) *taggingProvider {
+	return &taggingProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: delegate},
+		metadata:           metadata,
+	}
+}
+
+// BooleanEvaluation evaluates a boolean flag and tags its flag metadata
+func (p *taggingProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	res := p.FeatureProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+	res.FlagMetadata = p.tag(res.FlagMetadata)
+	return res
+}
+
+// StringEvaluation evaluates a string flag and tags its flag metadata
+func (p *taggingProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	res := p.FeatureProvider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+	res.FlagMetadata = p.tag(res.FlagMetadata)
+	return res
+}
+
+// FloatEvaluation evaluates a float flag and tags its flag metadata
+func (p *taggingProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	res := p.FeatureProvider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+	res.FlagMetadata = p.tag(res.FlagMetadata)
+	return res
+}
+
+// IntEvaluation evaluates an int flag and tags its flag metadata
+func (p *taggingProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	res := p.FeatureProvider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+	res.FlagMetadata = p.tag(res.FlagMetadata)
+	return res
+}
+
+// ObjectEvaluation evaluates an object flag and tags its flag metadata
+func (p *taggingProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	res := p.FeatureProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+	res.FlagMetadata = p.tag(res.FlagMetadata)
+	return res
+}
+
+// untagged returns the provider wrapped by SetProviderWithMetadata, if any, so that a provider is identified as the
+// same provider whether it was set with metadata or not
+func untagged(provider FeatureProvider) FeatureProvider {
+	if tagging, ok := provider.(*taggingProvider); ok {
+		return tagging.FeatureProvider
+	}
+	return provider
+}
+
+// tagEvent returns the event with its metadata completed with the metadata attached to the provider, if it was set
+// with SetProviderWithMetadata
+func tagEvent(provider FeatureProvider, event Event) Event {
+	if tagging, ok := provider.(*taggingProvider); ok {
+		event.EventMetadata = tagging.tag(event.EventMetadata)
+	}
+	return event
+}
+
+// tag returns a copy of metadata completed with the attached metadata
+func (p *taggingProvider) tag(metadata map[string]interface{}) map[string]interface{} {
+	tagged := make(map[string]interface{}, len(metadata)+len(p.metadata))
+	for key, value := range p.metadata {
+		tagged[key] = value
+	}
+	for key, value := range metadata {
+		tagged[key] = value
+	}
+	return tagged
+}
@@ -0,0 +1,161 @@
+package openfeature
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetProviderWithMetadata(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	eventing := &ProviderEventing{c: make(chan Event, 1)}
+	provider := struct {
+		FeatureProvider
+		EventHandler
+	}{
+		NoopProvider{},
+		eventing,
+	}
+
+	if err := SetProviderWithMetadata(provider, map[string]interface{}{"region": "eu-west-1", "team": "payments"}); err != nil {
+		t.Fatalf("failed to set up provider: %v", err)
+	}
+
+	client := GetApiInstance().GetClient()
+	eventually(t, func() bool {
+		return client.State() == ReadyState
+	}, time.Second, 10*time.Millisecond, "provider not ready")
+
+	t.Run("evaluations are tagged", func(t *testing.T) {
+		details, err := client.BooleanValueDetails(context.Background(), "flag", false, EvaluationContext{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		region, err := details.FlagMetadata.GetString("region")
+		if err != nil || region != "eu-west-1" {
+			t.Errorf("expected flag metadata to be tagged with the region, got %v", details.FlagMetadata)
+		}
+	})
+
+	t.Run("events are tagged", func(t *testing.T) {
+		var mu sync.Mutex
+		var metadata map[string]interface{}
+		callback := func(details EventDetails) {
+			mu.Lock()
+			defer mu.Unlock()
+			metadata = details.EventMetadata
+		}
+		client.AddHandler(ProviderConfigChange, &callback)
+
+		eventing.Invoke(Event{
+			EventType: ProviderConfigChange,
+			ProviderEventDetails: ProviderEventDetails{
+				EventMetadata: map[string]interface{}{"region": "overridden", "version": 2},
+			},
+		})
+
+		eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return metadata != nil
+		}, time.Second, 10*time.Millisecond, "event not received")
+
+		mu.Lock()
+		defer mu.Unlock()
+		if metadata["team"] != "payments" {
+			t.Errorf("expected event metadata to be tagged with the team, got %v", metadata)
+		}
+		if metadata["region"] != "overridden" || metadata["version"] != 2 {
+			t.Errorf("expected provider event metadata to take precedence, got %v", metadata)
+		}
+	})
+}
+
+func TestSetProviderWithMetadataInitEvents(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	metadata := make(chan map[string]interface{}, 1)
+	callback := func(details EventDetails) {
+		metadata <- details.EventMetadata
+	}
+	AddHandler(ProviderReady, &callback)
+
+	provider := struct {
+		FeatureProvider
+		StateHandler
+	}{NoopProvider{}, &stateHandlerForTests{}}
+	if err := SetProviderWithMetadata(provider, map[string]interface{}{"region": "eu-west-1"}); err != nil {
+		t.Fatalf("failed to set up provider: %v", err)
+	}
+
+	select {
+	case got := <-metadata:
+		if got["region"] != "eu-west-1" {
+			t.Errorf("expected the ready event to be tagged with the region, got %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ready event not received")
+	}
+}
+
+func TestSetProviderWithMetadataSharedBinding(t *testing.T) {
+	defer t.Cleanup(initSingleton)
+
+	var mu sync.Mutex
+	shutdowns := 0
+	eventing := &ProviderEventing{c: make(chan Event, 1)}
+	provider := &struct {
+		FeatureProvider
+		*stateHandlerForTests
+		*ProviderEventing
+	}{
+		NoopProvider{},
+		&stateHandlerForTests{shutdownF: func() {
+			mu.Lock()
+			defer mu.Unlock()
+			shutdowns++
+		}},
+		eventing,
+	}
+
+	if err := SetNamedProviderAndWait("shared", provider); err != nil {
+		t.Fatalf("failed to set up provider: %v", err)
+	}
+	if err := SetProviderWithMetadata(provider, map[string]interface{}{"region": "eu-west-1"}); err != nil {
+		t.Fatalf("failed to set up provider: %v", err)
+	}
+
+	received := make(chan string, 2)
+	defaultCallback := func(details EventDetails) { received <- "default" }
+	namedCallback := func(details EventDetails) { received <- "named" }
+	GetApiInstance().GetClient().AddHandler(ProviderConfigChange, &defaultCallback)
+	GetApiInstance().GetNamedClient("shared").AddHandler(ProviderConfigChange, &namedCallback)
+
+	eventing.Invoke(Event{EventType: ProviderConfigChange})
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case domain := <-received:
+			got[domain] = true
+		case <-time.After(time.Second):
+			t.Fatalf("expected the event to reach both domains, got %v", got)
+		}
+	}
+	if !got["default"] || !got["named"] {
+		t.Errorf("expected the event to reach both domains, got %v", got)
+	}
+
+	// replacing the default provider must not shut down the provider still bound to the named domain
+	if err := SetProviderAndWait(NoopProvider{}); err != nil {
+		t.Fatalf("failed to set up provider: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if shutdowns != 0 {
+		t.Errorf("expected the shared provider not to be shut down, got %d shutdowns", shutdowns)
+	}
+}
@@ -0,0 +1,104 @@
+package testing
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// FakeEventProvider is a provider whose events are pushed by tests, to test event driven code deterministically.
+// Flags evaluate to their default value.
+type FakeEventProvider struct {
+	openfeature.NoopProvider
+	events chan openfeature.Event
+}
+
+// NewFakeEventProvider creates a new FakeEventProvider
+func NewFakeEventProvider() *FakeEventProvider {
+	return &FakeEventProvider{
+		events: make(chan openfeature.Event),
+	}
+}
+
+// Metadata returns the metadata of the provider
+func (p *FakeEventProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: "FakeEventProvider"}
+}
+
+// EventChannel returns the channel the SDK receives the emitted events from
+func (p *FakeEventProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// Emit emits an event of the given type. It returns once the SDK received the event, so the provider must be set
+// with SetProvider or SetNamedProvider beforehand. Handlers run asynchronously, use a HandlerRecorder to wait for
+// them.
+func (p *FakeEventProvider) Emit(eventType openfeature.EventType, details openfeature.ProviderEventDetails) {
+	p.events <- openfeature.Event{
+		ProviderName:         p.Metadata().Name,
+		EventType:            eventType,
+		ProviderEventDetails: details,
+	}
+}
+
+// HandlerRecorder records the events delivered to its callback, and lets tests wait for them without sleeping
+type HandlerRecorder struct {
+	mu       sync.Mutex
+	events   []openfeature.EventDetails
+	recorded chan struct{}
+	callback func(details openfeature.EventDetails)
+}
+
+// NewHandlerRecorder creates a new HandlerRecorder
+func NewHandlerRecorder() *HandlerRecorder {
+	recorder := &HandlerRecorder{
+		recorded: make(chan struct{}, 1),
+	}
+	recorder.callback = func(details openfeature.EventDetails) {
+		recorder.mu.Lock()
+		recorder.events = append(recorder.events, details)
+		recorder.mu.Unlock()
+
+		select {
+		case recorder.recorded <- struct{}{}:
+		default:
+		}
+	}
+	return recorder
+}
+
+// Callback returns the callback to register with AddHandler
+func (r *HandlerRecorder) Callback() openfeature.EventCallback {
+	return &r.callback
+}
+
+// Events returns the events recorded so far
+func (r *HandlerRecorder) Events() []openfeature.EventDetails {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]openfeature.EventDetails(nil), r.events...)
+}
+
+// WaitForEvents waits until at least n events are recorded and returns them. The test fails if they are not recorded
+// within timeout.
+func (r *HandlerRecorder) WaitForEvents(t testing.TB, n int, timeout time.Duration) []openfeature.EventDetails {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		if events := r.Events(); len(events) >= n {
+			return events
+		}
+
+		select {
+		case <-r.recorded:
+		case <-timer.C:
+			t.Fatalf("expected %d events within %s, got %d", n, timeout, len(r.Events()))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestFakeEventProvider(t *testing.T) {
+	provider := NewFakeEventProvider()
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatalf("unable to set provider: %v", err)
+	}
+
+	client := openfeature.NewClient(t.Name())
+	staleRecorder := NewHandlerRecorder()
+	configRecorder := NewHandlerRecorder()
+	client.AddHandler(openfeature.ProviderStale, staleRecorder.Callback())
+	client.AddHandler(openfeature.ProviderConfigChange, configRecorder.Callback())
+
+	provider.Emit(openfeature.ProviderConfigChange, openfeature.ProviderEventDetails{FlagChanges: []string{"flag"}})
+
+	events := configRecorder.WaitForEvents(t, 1, time.Second)
+	if events[0].ProviderName != "FakeEventProvider" || events[0].FlagChanges[0] != "flag" {
+		t.Errorf("unexpected event details %+v", events[0])
+	}
+
+	provider.Emit(openfeature.ProviderStale, openfeature.ProviderEventDetails{})
+	staleRecorder.WaitForEvents(t, 1, time.Second)
+
+	if client.State() != openfeature.StaleState {
+		t.Errorf("expected client state %s, got %s", openfeature.StaleState, client.State())
+	}
+	if len(configRecorder.Events()) != 1 {
+		t.Errorf("expected the configuration change handler to fire once, fired %d times", len(configRecorder.Events()))
+	}
+}
@@ -35,9 +35,13 @@ func (tp TestProvider) UsingFlags(test *testing.T, flags map[string]memprovider.
 }
 
 // Cleanup deletes the flags provider bound to the current test and should be executed after each test execution
-// e.g. using a defer statement.
+// e.g. using a defer statement. The provider is reset beforehand if it is openfeature.Resettable.
 func (tp TestProvider) Cleanup() {
-	tp.providers.Delete(getGoroutineLocal())
+	if provider, ok := tp.providers.LoadAndDelete(getGoroutineLocal()); ok {
+		if resettable, ok := provider.(openfeature.Resettable); ok {
+			resettable.Reset()
+		}
+	}
 	deleteGoroutineLocal()
 }
 
@@ -165,3 +165,28 @@ func functionUnderTest() bool {
 		Boolean(context.TODO(), "my_flag", false, openfeature.EvaluationContext{})
 	return got
 }
+
+type resettableProvider struct {
+	openfeature.NoopProvider
+	resets *int
+}
+
+func (p resettableProvider) Reset() {
+	*p.resets++
+}
+
+func TestCleanupResetsProvider(t *testing.T) {
+	testProvider := NewTestProvider()
+	storeGoroutineLocal(t.Name())
+
+	var resets int
+	testProvider.providers.Store(t.Name(), resettableProvider{resets: &resets})
+	testProvider.Cleanup()
+
+	if resets != 1 {
+		t.Errorf("expected the provider to be reset once, got %d", resets)
+	}
+	if _, ok := testProvider.providers.Load(t.Name()); ok {
+		t.Error("expected the provider to be deleted")
+	}
+}
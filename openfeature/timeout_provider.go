@@ -0,0 +1,103 @@
+package openfeature
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutProvider is a FeatureProvider decorator bounding the duration of each flag evaluation of the wrapped
+// provider. Lifecycle, eventing and tracking are delegated to the wrapped provider.
+type TimeoutProvider struct {
+	delegatingProvider
+	timeout time.Duration
+}
+
+// NewTimeoutProvider wraps the given provider so that each evaluation taking longer than timeout resolves to the
+// default value with a GENERAL error.
+//
+// The evaluation of the wrapped provider runs in its own goroutine, and receives a context canceled once the
+// timeout elapses. A timed out evaluation is abandoned, not interrupted: its goroutine runs until the wrapped
+// provider returns, and its result is discarded. Providers should therefore honor context cancellation.
+func NewTimeoutProvider(delegate FeatureProvider, timeout time.Duration) *TimeoutProvider {
+	return &TimeoutProvider{
+		delegatingProvider: delegatingProvider{FeatureProvider: delegate},
+		timeout:            timeout,
+	}
+}
+
+// BooleanEvaluation evaluates a boolean flag within the timeout
+func (p *TimeoutProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	return withTimeout(ctx, p.timeout, flag, func(ctx context.Context) BoolResolutionDetail {
+		return p.FeatureProvider.BooleanEvaluation(ctx, flag, defaultValue, evalCtx)
+	}, func(detail ProviderResolutionDetail) BoolResolutionDetail {
+		return BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	})
+}
+
+// StringEvaluation evaluates a string flag within the timeout
+func (p *TimeoutProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	return withTimeout(ctx, p.timeout, flag, func(ctx context.Context) StringResolutionDetail {
+		return p.FeatureProvider.StringEvaluation(ctx, flag, defaultValue, evalCtx)
+	}, func(detail ProviderResolutionDetail) StringResolutionDetail {
+		return StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	})
+}
+
+// FloatEvaluation evaluates a float flag within the timeout
+func (p *TimeoutProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	return withTimeout(ctx, p.timeout, flag, func(ctx context.Context) FloatResolutionDetail {
+		return p.FeatureProvider.FloatEvaluation(ctx, flag, defaultValue, evalCtx)
+	}, func(detail ProviderResolutionDetail) FloatResolutionDetail {
+		return FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	})
+}
+
+// IntEvaluation evaluates an int flag within the timeout
+func (p *TimeoutProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	return withTimeout(ctx, p.timeout, flag, func(ctx context.Context) IntResolutionDetail {
+		return p.FeatureProvider.IntEvaluation(ctx, flag, defaultValue, evalCtx)
+	}, func(detail ProviderResolutionDetail) IntResolutionDetail {
+		return IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	})
+}
+
+// ObjectEvaluation evaluates an object flag within the timeout
+func (p *TimeoutProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	return withTimeout(ctx, p.timeout, flag, func(ctx context.Context) InterfaceResolutionDetail {
+		return p.FeatureProvider.ObjectEvaluation(ctx, flag, defaultValue, evalCtx)
+	}, func(detail ProviderResolutionDetail) InterfaceResolutionDetail {
+		return InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	})
+}
+
+// withTimeout runs evaluate in its own goroutine and waits for its result at most timeout. On timeout, or if ctx is
+// done first, the result of onTimeout is returned.
+func withTimeout[T any](
+	ctx context.Context,
+	timeout time.Duration,
+	flag string,
+	evaluate func(ctx context.Context) T,
+	onTimeout func(detail ProviderResolutionDetail) T,
+) T {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// buffered, so that an abandoned evaluation can complete without a receiver
+	result := make(chan T, 1)
+	go func() {
+		result <- evaluate(ctx)
+	}()
+
+	select {
+	case detail := <-result:
+		return detail
+	case <-ctx.Done():
+		return onTimeout(ProviderResolutionDetail{
+			ResolutionError: NewGeneralResolutionError(
+				fmt.Sprintf("evaluation of flag %q did not complete within %s", flag, timeout),
+			).WithCause(ctx.Err()),
+			Reason: ErrorReason,
+		})
+	}
+}
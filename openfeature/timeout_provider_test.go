@@ -0,0 +1,80 @@
+package openfeature
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// slowProvider is a provider taking delay to evaluate boolean flags, unless its context is done first
+type slowProvider struct {
+	NoopProvider
+	delay    time.Duration
+	canceled chan struct{}
+}
+
+func (p slowProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	select {
+	case <-time.After(p.delay):
+		return BoolResolutionDetail{
+			Value:                    !defaultValue,
+			ProviderResolutionDetail: ProviderResolutionDetail{Reason: StaticReason},
+		}
+	case <-ctx.Done():
+		close(p.canceled)
+		return BoolResolutionDetail{Value: defaultValue}
+	}
+}
+
+func TestTimeoutProvider(t *testing.T) {
+	t.Run("evaluation within timeout returns the delegate result", func(t *testing.T) {
+		provider := NewTimeoutProvider(slowProvider{canceled: make(chan struct{})}, time.Second)
+
+		detail := provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+		if !detail.Value || detail.Reason != StaticReason {
+			t.Errorf("expected the delegate result, got %+v", detail)
+		}
+	})
+
+	t.Run("evaluation exceeding timeout returns the default with an error", func(t *testing.T) {
+		delegate := slowProvider{delay: time.Minute, canceled: make(chan struct{})}
+		provider := NewTimeoutProvider(delegate, 10*time.Millisecond)
+
+		detail := provider.BooleanEvaluation(context.Background(), "flag", false, FlattenedContext{})
+		if detail.Value {
+			t.Error("expected the default value")
+		}
+		if detail.Reason != ErrorReason {
+			t.Errorf("expected reason %s, got %s", ErrorReason, detail.Reason)
+		}
+		if detail.ResolutionError.code != GeneralCode {
+			t.Errorf("expected error code %s, got %s", GeneralCode, detail.ResolutionError.code)
+		}
+		if !errors.Is(detail.Error(), context.DeadlineExceeded) {
+			t.Errorf("expected error caused by %v, got %v", context.DeadlineExceeded, detail.Error())
+		}
+
+		select {
+		case <-delegate.canceled:
+		case <-time.After(time.Second):
+			t.Error("expected the abandoned evaluation context to be canceled")
+		}
+	})
+
+	t.Run("delegates lifecycle", func(t *testing.T) {
+		delegate := &stateHandlerForTests{
+			initF: func(e EvaluationContext) error {
+				return errors.New("init failed")
+			},
+		}
+		provider := NewTimeoutProvider(struct {
+			FeatureProvider
+			StateHandler
+		}{NoopProvider{}, delegate}, time.Second)
+
+		if err := provider.Init(EvaluationContext{}); err == nil {
+			t.Error("expected the delegate init error")
+		}
+	})
+}